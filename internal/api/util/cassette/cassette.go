@@ -0,0 +1,239 @@
+// Package cassette records the CLI commands and HTTP requests of a test case to a file, and serves them back
+// in place of the network, so helpers parsing their output can be developed and regression tested offline.
+package cassette
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/util/redact"
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+// ModeEnv contains name of env variable selecting the cassette mode, "record" or "replay". Cassettes are off by default.
+const ModeEnv = "CASSETTE_MODE"
+
+// DirEnv contains name of env variable with the directory cassettes are written to and read from
+const DirEnv = "CASSETTE_DIR"
+
+const defaultDir = "cassettes"
+
+// Cassette modes
+const (
+	ModeRecord = "record"
+	ModeReplay = "replay"
+)
+
+// Kinds of interactions
+const (
+	KindCLI  = "cli"
+	KindHTTP = "http"
+	// KindProcess is a long-running command, recorded with its output once it has exited
+	KindProcess = "process"
+)
+
+// Interaction is a single CLI command or HTTP request and its outcome
+type Interaction struct {
+	Kind string `json:"kind"`
+	// Request is the command string, or the method and URL of an HTTP request
+	Request string `json:"request"`
+	Body    string `json:"body,omitempty"`
+
+	Stdout   string `json:"stdout,omitempty"`
+	Stderr   string `json:"stderr,omitempty"`
+	Output   string `json:"output,omitempty"`
+	ExitCode int    `json:"exit_code,omitempty"`
+
+	Status  int                 `json:"status,omitempty"`
+	Headers map[string][]string `json:"headers,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// Cassette holds the interactions of a test case
+type Cassette struct {
+	mutex        sync.Mutex
+	name         string
+	path         string
+	seed         int64
+	interactions []Interaction
+	// replay queues interactions by request, so repeated requests are served in the order they were recorded
+	replay map[string][]Interaction
+}
+
+type file struct {
+	Test         string        `json:"test"`
+	Seed         int64         `json:"seed"`
+	Interactions []Interaction `json:"interactions"`
+}
+
+var (
+	cassettesMutex sync.Mutex
+	cassettes      = make(map[string]*Cassette)
+)
+
+// Mode returns the cassette mode selected by CASSETTE_MODE
+func Mode() string {
+	switch mode := os.Getenv(ModeEnv); mode {
+	case ModeRecord, ModeReplay:
+		return mode
+	default:
+		return ""
+	}
+}
+
+// Recording reports whether interactions are recorded to cassettes
+func Recording() bool {
+	return Mode() == ModeRecord
+}
+
+// Replaying reports whether interactions are served from cassettes instead of the network
+func Replaying() bool {
+	return Mode() == ModeReplay
+}
+
+// For returns the cassette of the test case, or nil if cassettes are off.
+// A recorded cassette is written once the test case and its cleanup functions have finished.
+func For(t *test.SystemTest) *Cassette {
+	if Mode() == "" {
+		return nil
+	}
+
+	name := t.Name()
+	cassettesMutex.Lock()
+	defer cassettesMutex.Unlock()
+
+	if c, ok := cassettes[name]; ok {
+		return c
+	}
+
+	c := &Cassette{name: name, path: path(name), seed: test.RunSeed()}
+	cassettes[name] = c
+
+	if Replaying() {
+		if err := c.load(); err != nil {
+			t.Errorf("Failed to load cassette [%s] due to error: %v", c.path, err)
+		}
+	}
+
+	t.Cleanup(func() {
+		cassettesMutex.Lock()
+		delete(cassettes, name)
+		cassettesMutex.Unlock()
+
+		if Recording() {
+			if err := c.save(); err != nil {
+				t.Errorf("Failed to write cassette [%s] due to error: %v", c.path, err)
+			}
+		}
+	})
+
+	return c
+}
+
+// ForContext returns the cassette of the test case carried by ctx, see test.SystemTest.Context, or nil if there is
+// none or cassettes are off
+func ForContext(ctx context.Context) *Cassette {
+	if c, ok := ctx.Value(contextKey{}).(*Cassette); ok {
+		return c
+	}
+	if t := test.FromContext(ctx); t != nil {
+		return For(t)
+	}
+	return nil
+}
+
+// Record adds an interaction to the cassette
+func (c *Cassette) Record(interaction Interaction) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.interactions = append(c.interactions, interaction)
+}
+
+// Replay returns the next recorded interaction matching the request
+func (c *Cassette) Replay(kind, request, body string) (Interaction, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// cassettes are redacted when written, so the request is too before looking it up
+	k := key(kind, redact.String(request), redact.String(body))
+	queue := c.replay[k]
+	if len(queue) == 0 {
+		return Interaction{}, fmt.Errorf("no recorded %s interaction for [%s] in cassette [%s], it was recorded with %s=%d", kind, request, c.path, test.SeedEnv, c.seed)
+	}
+	c.replay[k] = queue[1:]
+	return queue[0], nil
+}
+
+func (c *Cassette) load() error {
+	content, err := os.ReadFile(c.path)
+	if err != nil {
+		return err
+	}
+
+	var f file
+	if err := json.Unmarshal(content, &f); err != nil {
+		return err
+	}
+
+	c.seed = f.Seed
+	if f.Seed != test.RunSeed() {
+		log.Printf("Cassette [%s] was recorded with %s=%d, random test inputs may not match", c.path, test.SeedEnv, f.Seed)
+	}
+	c.replay = make(map[string][]Interaction)
+	for _, interaction := range f.Interactions {
+		k := key(interaction.Kind, interaction.Request, interaction.Body)
+		c.replay[k] = append(c.replay[k], interaction)
+	}
+	return nil
+}
+
+func (c *Cassette) save() error {
+	c.mutex.Lock()
+	interactions := make([]Interaction, 0, len(c.interactions))
+	for _, interaction := range c.interactions {
+		interactions = append(interactions, redacted(interaction))
+	}
+	c.mutex.Unlock()
+
+	content, err := json.MarshalIndent(file{Test: c.name, Seed: c.seed, Interactions: interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil { //nolint:gosec
+		return err
+	}
+	return os.WriteFile(c.path, content, 0644) //nolint:gosec
+}
+
+// redacted masks the secrets in the interaction, as cassettes are shared like logs
+func redacted(interaction Interaction) Interaction {
+	interaction.Request = redact.String(interaction.Request)
+	interaction.Body = redact.String(interaction.Body)
+	interaction.Stdout = redact.String(interaction.Stdout)
+	interaction.Stderr = redact.String(interaction.Stderr)
+	interaction.Output = redact.String(interaction.Output)
+	interaction.Error = redact.String(interaction.Error)
+	return interaction
+}
+
+func key(kind, request, body string) string {
+	return kind + "\x00" + request + "\x00" + body
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+func path(testName string) string {
+	dir := os.Getenv(DirEnv)
+	if dir == "" {
+		dir = defaultDir
+	}
+	return filepath.Join(dir, unsafeFileNameChars.ReplaceAllString(testName, "_")+".json")
+}
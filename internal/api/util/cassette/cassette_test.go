@@ -0,0 +1,75 @@
+package cassette
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/redact"
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+func TestRecordAndReplayWithTestContext(t *testing.T) {
+	secret := "cassette-test-secret-value"
+	redact.Register(secret)
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		_, _ = io.WriteString(w, `{"key":"`+secret+`"}`)
+	}))
+	defer server.Close()
+
+	t.Setenv(DirEnv, t.TempDir())
+	client := &http.Client{Transport: Transport(nil)}
+	get := func(s *test.SystemTest) string {
+		req, err := http.NewRequestWithContext(s.Context(), http.MethodGet, server.URL+"/v1/"+secret, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		body, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(body)
+	}
+
+	t.Setenv(ModeEnv, ModeRecord)
+	s := test.NewSystemTest(t)
+	s.RunSequentially("case", func(s *test.SystemTest) {
+		if body := get(s); !strings.Contains(body, secret) {
+			t.Errorf("expected the live response, got %s", body)
+		}
+	})
+
+	content, err := os.ReadFile(path(t.Name() + "/case"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(content), secret) {
+		t.Errorf("expected the secret to be redacted in the cassette, got %s", content)
+	}
+
+	// the case run again is named case#01
+	if err := os.WriteFile(path(t.Name()+"/case#01"), content, 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(ModeEnv, ModeReplay)
+	s.RunSequentially("case", func(s *test.SystemTest) {
+		if body := get(s); !strings.Contains(body, redact.Secret(secret)) {
+			t.Errorf("expected the redacted recorded response, got %s", body)
+		}
+	})
+	if requests := atomic.LoadInt32(&requests); requests != 1 {
+		t.Errorf("expected the replayed request not to reach the server, got %d requests", requests)
+	}
+}
@@ -0,0 +1,125 @@
+package cassette
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+type contextKey struct{}
+
+// Context returns the context of the test case, carrying its cassette to HTTP requests sent through Transport.
+// The context of the test case, t.Context(), carries its cassette as well.
+func Context(t *test.SystemTest) context.Context {
+	c := For(t)
+	if c == nil {
+		return t.Context()
+	}
+	return context.WithValue(t.Context(), contextKey{}, c)
+}
+
+// Transport records HTTP requests carrying a cassette in their context, see ForContext, or serves them from it when replaying.
+// Requests without a cassette are sent through base, or http.DefaultTransport if base is nil.
+func Transport(base http.RoundTripper) http.RoundTripper {
+	return &transport{base: base}
+}
+
+type transport struct {
+	base http.RoundTripper
+}
+
+func (tr *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := tr.base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	c := ForContext(req.Context())
+	if c == nil {
+		return base.RoundTrip(req)
+	}
+
+	body, err := requestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	request := req.Method + " " + req.URL.String()
+
+	if Replaying() {
+		interaction, err := c.Replay(KindHTTP, request, body)
+		if err != nil {
+			return nil, err
+		}
+		if interaction.Error != "" {
+			return nil, errors.New(interaction.Error)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+			StatusCode:    interaction.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        http.Header(interaction.Headers),
+			Body:          io.NopCloser(strings.NewReader(interaction.Output)),
+			ContentLength: int64(len(interaction.Output)),
+			Request:       req,
+		}, nil
+	}
+
+	resp, err := base.RoundTrip(req)
+	if err != nil {
+		c.Record(Interaction{Kind: KindHTTP, Request: request, Body: body, Error: err.Error()})
+		return nil, err
+	}
+
+	responseBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(responseBody))
+	if err != nil {
+		return nil, err
+	}
+
+	c.Record(Interaction{Kind: KindHTTP, Request: request, Body: body, Status: resp.StatusCode, Headers: resp.Header, Output: string(responseBody)})
+	return resp, nil
+}
+
+// requestBody reads the body of the request, leaving it readable for the transport.
+// The random boundary of multipart bodies is replaced, so they match when replayed.
+func requestBody(req *http.Request) (string, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return "", nil
+	}
+
+	var content []byte
+	if req.GetBody != nil {
+		reader, err := req.GetBody()
+		if err != nil {
+			return "", err
+		}
+		content, err = io.ReadAll(reader)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		var err error
+		content, err = io.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return "", err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(content))
+	}
+
+	body := string(content)
+	if _, params, err := mime.ParseMediaType(req.Header.Get("Content-Type")); err == nil && params["boundary"] != "" {
+		body = strings.ReplaceAll(body, params["boundary"], "BOUNDARY")
+	}
+	return body, nil
+}
@@ -9,6 +9,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/0chain/system_test/internal/api/util/cassette"
+	"github.com/0chain/system_test/internal/api/util/redact"
 	"github.com/0chain/system_test/internal/api/util/test"
 
 	"github.com/0chain/system_test/internal/api/model"
@@ -83,7 +85,7 @@ type APIClient struct {
 
 func NewAPIClient(networkEntrypoint string) *APIClient {
 	apiClient := &APIClient{}
-	apiClient.HttpClient = resty.New()
+	apiClient.HttpClient = resty.New().SetTransport(cassette.Transport(nil))
 
 	if err := apiClient.selectHealthyServiceProviders(networkEntrypoint); err != nil {
 		log.Fatalln(err)
@@ -127,7 +129,7 @@ func (c *APIClient) getHealthyNodes(nodes []string, serviceProviderType int) ([]
 			continue
 		}
 
-		log.Printf("%s is DOWN! Status: %d, Message: %s", node, status, string(response))
+		log.Printf("%s is DOWN! Status: %d, Message: %s", node, status, redact.String(string(response)))
 	}
 	return result, nil
 }
@@ -380,6 +382,10 @@ func (c *APIClient) V1TransactionGetConfirmation(t *test.SystemTest, transaction
 		HttpGETMethod,
 		SharderServiceProvider)
 
+	if err == nil && transactionGetConfirmationResponse != nil {
+		t.ObserveRound(transactionGetConfirmationResponse.Round)
+	}
+
 	return transactionGetConfirmationResponse, resp, err
 }
 
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 
+	"github.com/0chain/system_test/internal/api/util/cassette"
 	"github.com/0chain/system_test/internal/api/util/test"
 
 	"github.com/0chain/system_test/internal/api/model"
@@ -39,15 +40,15 @@ func (c *BaseHttpClient) executeForServiceProvider(t *test.SystemTest, url strin
 
 	switch method {
 	case HttpPUTMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetQueryParams(executionRequest.QueryParams).SetBody(executionRequest.Body).Put(url)
+		resp, err = c.HttpClient.R().SetContext(cassette.Context(t)).SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetQueryParams(executionRequest.QueryParams).SetBody(executionRequest.Body).Put(url)
 	case HttpPOSTMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetBody(executionRequest.Body).Post(url)
+		resp, err = c.HttpClient.R().SetContext(cassette.Context(t)).SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetBody(executionRequest.Body).Post(url)
 	case HttpFileUploadMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetFile(executionRequest.FileName, executionRequest.FilePath).Post(url)
+		resp, err = c.HttpClient.R().SetContext(cassette.Context(t)).SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetFile(executionRequest.FileName, executionRequest.FilePath).Post(url)
 	case HttpGETMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetQueryParams(executionRequest.QueryParams).Get(url)
+		resp, err = c.HttpClient.R().SetContext(cassette.Context(t)).SetHeaders(executionRequest.Headers).SetQueryParams(executionRequest.QueryParams).Get(url)
 	case HttpDELETEMethod:
-		resp, err = c.HttpClient.R().SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetBody(executionRequest.Body).Delete(url)
+		resp, err = c.HttpClient.R().SetContext(cassette.Context(t)).SetHeaders(executionRequest.Headers).SetFormData(executionRequest.FormData).SetBody(executionRequest.Body).Delete(url)
 	}
 
 	if err != nil {
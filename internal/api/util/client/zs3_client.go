@@ -1,6 +1,7 @@
 package client
 
 import (
+	"github.com/0chain/system_test/internal/api/util/cassette"
 	"github.com/0chain/system_test/internal/api/util/test"
 	resty "github.com/go-resty/resty/v2"
 )
@@ -17,13 +18,13 @@ type ZS3Client struct {
 
 func NewZS3Client(zs3ServerUrl string) *ZS3Client {
 	zs3Client := &ZS3Client{}
-	zs3Client.HttpClient = resty.New()
+	zs3Client.HttpClient = resty.New().SetTransport(cassette.Transport(nil))
 	zs3Client.zs3ServerUrl = zs3ServerUrl
 	return zs3Client
 }
 
 func (c *ZS3Client) BucketOperation(t *test.SystemTest, queryParams, formData map[string]string) (*resty.Response, error) {
-	resp, err := c.BaseHttpClient.HttpClient.R().SetFiles(formData).SetQueryParams(queryParams).Post(c.zs3ServerUrl)
+	resp, err := c.BaseHttpClient.HttpClient.R().SetContext(cassette.Context(t)).SetFiles(formData).SetQueryParams(queryParams).Post(c.zs3ServerUrl)
 	if err != nil {
 		t.Log(err)
 		return nil, err
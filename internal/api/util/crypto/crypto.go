@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/redact"
 	"github.com/0chain/system_test/internal/api/util/test"
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	"github.com/herumi/bls-go-binary/bls"
@@ -37,7 +38,8 @@ func GenerateMnemonics(t *test.SystemTest) string {
 	require.NoError(t, err)
 	mnemonic, err := bip39.NewMnemonic(entropy) //nolint
 	require.NoError(t, err)
-	t.Logf("Generated mnemonic [%s]", mnemonic)
+	redact.Register(mnemonic)
+	t.Logf("Generated mnemonic [%s]", redact.Secret(mnemonic))
 
 	return mnemonic
 }
@@ -61,7 +63,8 @@ func GenerateKeys(t *test.SystemTest, mnemonics string) *model.KeyPair {
 	secretKeyHex := secretKey.SerializeToHexStr()
 	publicKeyHex := publicKey.SerializeToHexStr()
 
-	t.Logf("Generated public key [%s] and secret key [%s]", publicKeyHex, secretKeyHex)
+	redact.Register(secretKeyHex)
+	t.Logf("Generated public key [%s] and secret key [%s]", publicKeyHex, redact.Secret(secretKeyHex))
 	bls.SetRandFunc(nil)
 
 	return &model.KeyPair{PublicKey: *publicKey, PrivateKey: secretKey}
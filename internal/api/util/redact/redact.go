@@ -0,0 +1,144 @@
+// Package redact masks secrets such as private keys, mnemonics, auth tickets and tokens in log output.
+// Each secret is replaced by a short fingerprint, so the same secret can still be correlated across log lines.
+package redact
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ShowSecretsEnv contains name of env variable which, when set to true, turns redaction off for local debugging
+const ShowSecretsEnv = "SHOW_SECRETS"
+
+const marker = "[REDACTED:"
+
+// secretNames are the keys of JSON fields, query parameters, headers and CLI flags holding secrets
+const secretNames = `private_?key|secret_?key|client_?private_?key|mnemonics?|auth_?ticket|auth_?token|` +
+	`x-csrf-token|csrf_?token|x-app-id-token|id_?token|refresh_?token|access_?token|firebase_?key|api_?key|password`
+
+var (
+	// quotedValueRegex matches "name": "value" in JSON
+	quotedValueRegex = regexp.MustCompile(`(?i)("(?:` + secretNames + `)"\s*:\s*")([^"]+)(")`)
+	// flagRegex matches --name value and --name=value, with the value optionally quoted
+	flagRegex = regexp.MustCompile(`(?i)(--(?:` + secretNames + `)(?:=|\s+))("[^"]*"|\S+)()`)
+	// assignmentRegex matches name=value, e.g. in query strings and logrus fields, with the value optionally quoted
+	assignmentRegex = regexp.MustCompile(`(?i)(\b(?:` + secretNames + `)\s*=\s*)("[^"]*"|'[^']*'|[^\s"'&,;}\]]+)()`)
+	// colonRegex matches name: value, e.g. in headers, YAML and CLI output, where the value runs over further words
+	// up to the end of the line, such as the words of a mnemonic
+	colonRegex = regexp.MustCompile(`(?i)(\b(?:` + secretNames + `)\s*:[ \t]*)("[^"]*"|'[^']*'|[^\s"'&,;}\]]+(?:[ \t]+[^\s"'&,;}\]]+)*)()`)
+
+	// standaloneRegexes match secrets recognisable by their format alone
+	standaloneRegexes = []*regexp.Regexp{
+		// auth tickets and JWT ID tokens are base64 encoded JSON
+		regexp.MustCompile(`\beyJ[A-Za-z0-9_\-+/=]{20,}(?:\.[A-Za-z0-9_\-+/=]+){0,2}`),
+		// Google API keys, e.g. Firebase web keys
+		regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`),
+	}
+)
+
+var (
+	secretsMutex sync.RWMutex
+	// secrets are kept longest first, so a secret containing another one is masked as a whole.
+	// Register replaces the slice instead of changing it, so String can range over it without holding the lock.
+	secrets []string
+
+	warnOnce sync.Once
+)
+
+// Enabled reports whether secrets are redacted, which is the case unless SHOW_SECRETS is true
+func Enabled() bool {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv(ShowSecretsEnv)), "true") {
+		warnOnce.Do(func() {
+			log.Printf("Secrets are shown in logs as %s is set, do not share these logs", ShowSecretsEnv)
+		})
+		return false
+	}
+	return true
+}
+
+// Register masks the secret wherever it appears in redacted output, e.g. a generated mnemonic or key
+func Register(secret string) {
+	if secret = strings.TrimSpace(secret); secret == "" {
+		return
+	}
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+	i := sort.Search(len(secrets), func(i int) bool {
+		return !secretBefore(secrets[i], secret)
+	})
+	if i < len(secrets) && secrets[i] == secret {
+		return
+	}
+	sorted := make([]string, 0, len(secrets)+1)
+	sorted = append(sorted, secrets[:i]...)
+	sorted = append(sorted, secret)
+	secrets = append(sorted, secrets[i:]...)
+}
+
+// secretBefore orders secrets longest first, then alphabetically
+func secretBefore(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a < b
+}
+
+// Fingerprint returns a short, stable identifier of the secret
+func Fingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:4])
+}
+
+// Secret returns the masked form of a known secret
+func Secret(secret string) string {
+	if !Enabled() {
+		return secret
+	}
+	return mask(secret)
+}
+
+func mask(secret string) string {
+	return marker + Fingerprint(secret) + "]"
+}
+
+// registeredSecrets returns the registered secrets longest first, the slice must not be modified
+func registeredSecrets() []string {
+	secretsMutex.RLock()
+	defer secretsMutex.RUnlock()
+	return secrets
+}
+
+// String masks registered secrets and values which look like secrets in the text
+func String(text string) string {
+	if text == "" || !Enabled() {
+		return text
+	}
+
+	for _, secret := range registeredSecrets() {
+		if strings.Contains(text, secret) {
+			text = strings.ReplaceAll(text, secret, mask(secret))
+		}
+	}
+
+	for _, regex := range []*regexp.Regexp{quotedValueRegex, flagRegex, assignmentRegex, colonRegex} {
+		text = regex.ReplaceAllStringFunc(text, func(match string) string {
+			parts := regex.FindStringSubmatch(match)
+			value := strings.Trim(parts[2], `"'`)
+			if strings.HasPrefix(value, marker) {
+				return match
+			}
+			return parts[1] + mask(value) + parts[3]
+		})
+	}
+
+	for _, regex := range standaloneRegexes {
+		text = regex.ReplaceAllStringFunc(text, mask)
+	}
+	return text
+}
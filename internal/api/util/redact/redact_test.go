@@ -0,0 +1,118 @@
+package redact
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	t.Setenv(ShowSecretsEnv, "")
+	mnemonic := "abandon ability able about above absent absorb abstract absurd abuse access accident"
+	Register("registered-key")
+	Register("registered-key-with-suffix")
+
+	tests := map[string]struct {
+		text    string
+		want    string
+		secrets []string
+	}{
+		"no secrets": {
+			text: "Balance: 1.000 ZCN (0.10 USD)",
+			want: "Balance: 1.000 ZCN (0.10 USD)",
+		},
+		"registered secret": {
+			text:    "using registered-key here",
+			want:    "using " + mask("registered-key") + " here",
+			secrets: []string{"registered-key"},
+		},
+		"longer registered secret is masked as a whole": {
+			text:    "using registered-key-with-suffix here",
+			want:    "using " + mask("registered-key-with-suffix") + " here",
+			secrets: []string{"registered-key-with-suffix"},
+		},
+		"json field": {
+			text:    `{"client_id":"abc","private_key":"0123456789abcdef"}`,
+			want:    `{"client_id":"abc","private_key":"` + mask("0123456789abcdef") + `"}`,
+			secrets: []string{"0123456789abcdef"},
+		},
+		"flag with quoted value": {
+			text:    `./zwallet recoverwallet --mnemonic "` + mnemonic + `" --silent`,
+			want:    `./zwallet recoverwallet --mnemonic ` + mask(mnemonic) + ` --silent`,
+			secrets: []string{mnemonic},
+		},
+		"flag with equals": {
+			text:    "./zbox download --authticket=abc123 --silent",
+			want:    "./zbox download --authticket=" + mask("abc123") + " --silent",
+			secrets: []string{"abc123"},
+		},
+		"query parameter": {
+			text:    "GET /v1/file?auth_token=tok123&path=/a",
+			want:    "GET /v1/file?auth_token=" + mask("tok123") + "&path=/a",
+			secrets: []string{"tok123"},
+		},
+		"quoted logrus field": {
+			text:    `level=info mnemonic="` + mnemonic + `" msg=done`,
+			want:    `level=info mnemonic=` + mask(mnemonic) + ` msg=done`,
+			secrets: []string{mnemonic},
+		},
+		"multi-word value after colon": {
+			text:    "Wallet created\nmnemonic: " + mnemonic + "\nClient ID: abc",
+			want:    "Wallet created\nmnemonic: " + mask(mnemonic) + "\nClient ID: abc",
+			secrets: []string{mnemonic},
+		},
+		"header": {
+			text:    "X-App-Id-Token: tokenvalue",
+			want:    "X-App-Id-Token: " + mask("tokenvalue"),
+			secrets: []string{"tokenvalue"},
+		},
+		"standalone token": {
+			text:    "ticket eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.payload.signature",
+			want:    "ticket " + mask("eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.payload.signature"),
+			secrets: []string{"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"},
+		},
+		"already masked": {
+			text: "private_key: " + mask("value"),
+			want: "private_key: " + mask("value"),
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := String(tt.text)
+			if got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+			for _, secret := range tt.secrets {
+				if strings.Contains(got, secret) {
+					t.Errorf("expected %q to be masked in %q", secret, got)
+				}
+			}
+			if again := String(got); again != got {
+				t.Errorf("expected redacted text to stay unchanged, got %q", again)
+			}
+		})
+	}
+}
+
+func TestRegisteredSecretsLongestFirst(t *testing.T) {
+	for _, secret := range []string{"sorted-b", "sorted-ccc", "sorted-aa", "sorted-dd", "sorted-aa"} {
+		Register(secret)
+	}
+	var sorted []string
+	for _, secret := range registeredSecrets() {
+		if strings.HasPrefix(secret, "sorted-") {
+			sorted = append(sorted, secret)
+		}
+	}
+	want := []string{"sorted-ccc", "sorted-aa", "sorted-dd", "sorted-b"}
+	if strings.Join(sorted, ",") != strings.Join(want, ",") {
+		t.Errorf("expected %v, got %v", want, sorted)
+	}
+}
+
+func TestStringShowSecrets(t *testing.T) {
+	t.Setenv(ShowSecretsEnv, "true")
+	text := "private_key=0123456789abcdef"
+	if got := String(text); got != text {
+		t.Errorf("expected secrets to be shown, got %q", got)
+	}
+}
@@ -0,0 +1,182 @@
+package test
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CLICapabilities describes what a CLI binary under test supports, so test cases needing newer
+// subcommands or flags are skipped when the suite runs against an older release.
+type CLICapabilities struct {
+	Binary  string
+	Version string
+	// Commands lists the subcommands of the binary
+	Commands map[string]bool
+	// Flags returns the flags of a subcommand, it is only called for subcommands required by test cases
+	Flags func(subcommand string) (map[string]bool, error)
+	// Err is why the capabilities could not be detected
+	Err error
+}
+
+var (
+	capabilitiesMutex sync.Mutex
+	capabilities      = make(map[string]*CLICapabilities)
+)
+
+// RegisterCLI makes the capabilities of a binary available to RequireCLI, usually from TestMain
+func RegisterCLI(c *CLICapabilities) {
+	capabilitiesMutex.Lock()
+	defer capabilitiesMutex.Unlock()
+	capabilities[c.Binary] = c
+}
+
+// RequireCLI skips the test unless the binary matches the version constraint, e.g. ">=1.8" or ">=1.8,<2",
+// and supports all features, each a subcommand optionally followed by its flags, e.g. "sync --uploadonly".
+// An empty constraint accepts any version. When the capabilities could not be detected, a warning is logged
+// and the test runs, so a broken detection cannot skip test cases unnoticed.
+func (s *SystemTest) RequireCLI(binary, versionConstraint string, features ...string) {
+	s.Unwrap.Helper()
+	reason, err := cliSkipReason(binary, versionConstraint, features)
+	if err != nil {
+		s.Logf("[WARN] Running without checking the requirements on [%s]: %v", binary, err)
+		return
+	}
+	if reason != "" {
+		s.Skip(reason)
+	}
+}
+
+// cliSkipReason returns why the binary does not meet the requirements, or an error if that cannot be told
+func cliSkipReason(binary, versionConstraint string, features []string) (string, error) {
+	capabilitiesMutex.Lock()
+	c, ok := capabilities[binary]
+	capabilitiesMutex.Unlock()
+
+	switch {
+	case !ok:
+		return "", fmt.Errorf("capabilities of [%s] were not detected", binary)
+	case c.Err != nil:
+		return "", fmt.Errorf("capabilities of [%s] could not be detected: %w", binary, c.Err)
+	}
+
+	if versionConstraint != "" {
+		matches, err := versionMatches(c.Version, versionConstraint)
+		if err != nil {
+			return "", fmt.Errorf("version [%s] cannot be checked against [%s]: %w", c.Version, versionConstraint, err)
+		}
+		if !matches {
+			return fmt.Sprintf("Skipping as [%s] version [%s] does not match [%s]", binary, c.Version, versionConstraint), nil
+		}
+	}
+
+	for _, feature := range features {
+		fields := strings.Fields(feature)
+		if len(fields) == 0 {
+			continue
+		}
+		subcommand := fields[0]
+		if !c.Commands[subcommand] {
+			return fmt.Sprintf("Skipping as [%s] version [%s] has no subcommand [%s]", binary, c.Version, subcommand), nil
+		}
+		if len(fields) == 1 {
+			continue
+		}
+		if c.Flags == nil {
+			return "", fmt.Errorf("flags of [%s %s] cannot be detected", binary, subcommand)
+		}
+
+		flags, err := c.Flags(subcommand)
+		if err != nil {
+			return "", fmt.Errorf("flags of [%s %s] could not be detected: %w", binary, subcommand, err)
+		}
+		for _, flag := range fields[1:] {
+			if !flags[strings.TrimLeft(flag, "-")] {
+				return fmt.Sprintf("Skipping as [%s %s] version [%s] has no flag [%s]", binary, subcommand, c.Version, flag), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+var constraintRegex = regexp.MustCompile(`^(>=|<=|==|=|>|<)?\s*v?(\d+(?:\.\d+)*)$`)
+
+// versionMatches checks a version against comma separated constraints, all of which must match
+func versionMatches(version, constraint string) (bool, error) {
+	v, err := parseVersion(version)
+	if err != nil {
+		return false, err
+	}
+
+	for _, part := range strings.Split(constraint, ",") {
+		match := constraintRegex.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil {
+			return false, fmt.Errorf("invalid version constraint [%s]", part)
+		}
+		bound, err := parseVersion(match[2])
+		if err != nil {
+			return false, err
+		}
+
+		cmp := compareVersions(v, bound)
+		var ok bool
+		switch match[1] {
+		case ">=":
+			ok = cmp >= 0
+		case "<=":
+			ok = cmp <= 0
+		case ">":
+			ok = cmp > 0
+		case "<":
+			ok = cmp < 0
+		default:
+			ok = cmp == 0
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+var versionRegex = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)`)
+
+// parseVersion reads the numeric part of a version, ignoring pre-release and build suffixes such as "-rc1"
+func parseVersion(version string) ([]int, error) {
+	match := versionRegex.FindStringSubmatch(strings.TrimSpace(version))
+	if match == nil {
+		return nil, fmt.Errorf("invalid version [%s]", version)
+	}
+
+	var parts []int
+	for _, part := range strings.Split(match[1], ".") {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, n)
+	}
+	return parts, nil
+}
+
+// compareVersions compares versions part by part, missing parts count as zero
+func compareVersions(a, b []int) int {
+	for i := 0; i < len(a) || i < len(b); i++ {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,66 @@
+package test
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Checks collects soft assertion failures, e.g. assert.Equal(t.Check(), expected, actual).
+// Unlike require, a failed check does not stop the test case: every failure is collected
+// and reported together once the current step or the test case ends.
+type Checks struct {
+	mutex    sync.Mutex
+	failures []string
+}
+
+// Check returns the soft assertion collector of this test case.
+func (s *SystemTest) Check() *Checks {
+	s.checksMutex.Lock()
+	defer s.checksMutex.Unlock()
+
+	if s.checks == nil {
+		s.checks = &Checks{}
+	}
+	return s.checks
+}
+
+// Errorf records a failed check. It satisfies assert.TestingT.
+func (c *Checks) Errorf(format string, args ...interface{}) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.failures = append(c.failures, strings.TrimSpace(fmt.Sprintf(format, args...)))
+}
+
+// Failed reports whether any check failed since the last report.
+func (c *Checks) Failed() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return len(c.failures) > 0
+}
+
+// reportChecks fails the test case with all soft assertion failures collected so far.
+func (s *SystemTest) reportChecks(scope string) {
+	s.checksMutex.Lock()
+	checks := s.checks
+	s.checksMutex.Unlock()
+	if checks == nil {
+		return
+	}
+
+	checks.mutex.Lock()
+	failures := checks.failures
+	checks.failures = nil
+	checks.mutex.Unlock()
+	if len(failures) == 0 {
+		return
+	}
+
+	var report strings.Builder
+	report.WriteString(strconv.Itoa(len(failures)) + " check(s) failed in " + scope + ":\n")
+	for i, failure := range failures {
+		report.WriteString(strconv.Itoa(i+1) + ". " + strings.ReplaceAll(failure, "\n", "\n   ") + "\n")
+	}
+	s.Error(report.String())
+}
@@ -0,0 +1,152 @@
+package test
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/redact"
+)
+
+// DiagnosticCommandCount is the number of most recent CLI commands dumped when a test case fails or times out
+var DiagnosticCommandCount = 10
+
+type StepResult struct {
+	Name     string    `json:"name"`
+	Started  time.Time `json:"started"`
+	Duration float64   `json:"duration_seconds"`
+	Failed   bool      `json:"failed"`
+}
+
+type commandRecord struct {
+	command  string
+	output   string
+	err      error
+	exitedAt time.Time
+}
+
+type diagnostics struct {
+	steps       []StepResult
+	activeSteps []string
+	commands    []commandRecord
+	round       int64
+	roundSeenAt time.Time
+}
+
+// Step runs f as a named step of the test case. Steps are logged, recorded in the test case result
+// and the step running when the test case fails or times out is reported in its diagnostics.
+func (s *SystemTest) Step(name string, f func()) {
+	s.Unwrap.Helper()
+	startedAt := time.Now()
+	failedBefore := s.Failed()
+
+	s.diagnosticsMutex.Lock()
+	s.diagnostics.activeSteps = append(s.diagnostics.activeSteps, name)
+	s.diagnosticsMutex.Unlock()
+	s.Logf("Step [%s] started", name)
+
+	defer func() {
+		s.reportChecks("step [" + name + "]")
+		step := StepResult{Name: name, Started: startedAt, Duration: time.Since(startedAt).Seconds(), Failed: !failedBefore && s.Failed()}
+
+		s.diagnosticsMutex.Lock()
+		s.diagnostics.steps = append(s.diagnostics.steps, step)
+		if n := len(s.diagnostics.activeSteps); n > 0 {
+			s.diagnostics.activeSteps = s.diagnostics.activeSteps[:n-1]
+		}
+		s.diagnosticsMutex.Unlock()
+
+		s.Logf("Step [%s] finished in [%v]", name, time.Since(startedAt).Round(time.Millisecond))
+	}()
+
+	f()
+}
+
+// RecordCommand keeps the command and its output, so the most recent ones can be dumped if the test case fails.
+func (s *SystemTest) RecordCommand(command, output string, err error) {
+	s.diagnosticsMutex.Lock()
+	defer s.diagnosticsMutex.Unlock()
+
+	if err != nil {
+		err = errors.New(redact.String(err.Error()))
+	}
+	record := commandRecord{command: redact.String(command), output: redact.String(output), err: err, exitedAt: time.Now()}
+	s.diagnostics.commands = append(s.diagnostics.commands, record)
+	if len(s.diagnostics.commands) > DiagnosticCommandCount {
+		s.diagnostics.commands = s.diagnostics.commands[len(s.diagnostics.commands)-DiagnosticCommandCount:]
+	}
+}
+
+// ObserveRound records the latest chain round seen by the test case, reported in its diagnostics.
+func (s *SystemTest) ObserveRound(round int64) {
+	s.diagnosticsMutex.Lock()
+	defer s.diagnosticsMutex.Unlock()
+
+	if round >= s.diagnostics.round {
+		s.diagnostics.round = round
+		s.diagnostics.roundSeenAt = time.Now()
+	}
+}
+
+func (s *SystemTest) stepResults() []StepResult {
+	s.diagnosticsMutex.Lock()
+	defer s.diagnosticsMutex.Unlock()
+	return append([]StepResult(nil), s.diagnostics.steps...)
+}
+
+// dumpDiagnostics logs a post-mortem of a failed or timed out test case.
+func (s *SystemTest) dumpDiagnostics(name string) {
+	s.diagnosticsMutex.Lock()
+	activeSteps := append([]string(nil), s.diagnostics.activeSteps...)
+	commands := append([]commandRecord(nil), s.diagnostics.commands...)
+	round, roundSeenAt := s.diagnostics.round, s.diagnostics.roundSeenAt
+	s.diagnosticsMutex.Unlock()
+
+	var report strings.Builder
+	report.WriteString("==== Diagnostics for test case [" + name + "] ====\n")
+	report.WriteString("Random seed: " + strconv.FormatInt(s.Seed(), 10) + " (replay with " + SeedEnv + "=" + strconv.FormatInt(RunSeed(), 10) + ")\n")
+
+	if len(activeSteps) > 0 {
+		report.WriteString("Current step: " + strings.Join(activeSteps, " > ") + "\n")
+	} else {
+		report.WriteString("Current step: none\n")
+	}
+
+	if round > 0 {
+		report.WriteString("Last observed round: " + strconv.FormatInt(round, 10) + " at " + roundSeenAt.Format("01-02-2006 15:04:05") + "\n")
+	} else {
+		report.WriteString("Last observed round: none\n")
+	}
+
+	report.WriteString("Last " + strconv.Itoa(len(commands)) + " command(s):\n")
+	for _, command := range commands {
+		report.WriteString("  [" + command.exitedAt.Format("15:04:05") + "] " + command.command + "\n")
+		if command.err != nil {
+			report.WriteString("    error: " + command.err.Error() + "\n")
+		}
+		for _, line := range strings.Split(strings.TrimSpace(command.output), "\n") {
+			report.WriteString("    | " + line + "\n")
+		}
+	}
+
+	report.WriteString("Goroutines:\n")
+	report.WriteString(goroutineStacks())
+
+	s.Log(report.String())
+}
+
+func goroutineStacks() string {
+	buf := make([]byte, 1<<16)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		if len(buf) >= 1<<24 {
+			return string(buf[:n]) + "\n... truncated"
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
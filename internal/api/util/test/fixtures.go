@@ -0,0 +1,233 @@
+package test
+
+import (
+	"fmt"
+	"log"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Scope defines how widely the value of a fixture is shared
+type Scope int
+
+const (
+	// CaseScope fixtures are created for every test case requesting them
+	CaseScope Scope = iota
+	// TestScope fixtures are shared by all test cases of the same top-level test function
+	TestScope
+	// SuiteScope fixtures are shared by all test cases of the test package
+	SuiteScope
+)
+
+func (s Scope) String() string {
+	switch s {
+	case TestScope:
+		return "test"
+	case SuiteScope:
+		return "suite"
+	default:
+		return "case"
+	}
+}
+
+// Fixture declares a resource a test case needs, e.g. a funded wallet or an allocation.
+// It is created lazily by the first test case calling Get and shared by every test case within its scope.
+// Teardown, if set, is run once the scope ends: when the test case, the top-level test or the test package
+// (see TeardownSuiteFixtures) finishes. Setup must not rely on t.Cleanup for fixtures wider than CaseScope.
+type Fixture[T any] struct {
+	Name     string
+	Scope    Scope
+	Setup    func(t *SystemTest) T
+	Teardown func(t *SystemTest, value T)
+}
+
+type fixtureEntry struct {
+	mutex   sync.Mutex
+	created bool
+	value   any
+}
+
+type fixtureStore struct {
+	mutex   sync.Mutex
+	entries map[any]*fixtureEntry
+}
+
+func (f *fixtureStore) entry(key any) *fixtureEntry {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.entries == nil {
+		f.entries = make(map[any]*fixtureEntry)
+	}
+	entry, ok := f.entries[key]
+	if !ok {
+		entry = &fixtureEntry{}
+		f.entries[key] = entry
+	}
+	return entry
+}
+
+var (
+	suiteFixtures         = &fixtureStore{}
+	suiteTeardownsMutex   sync.Mutex
+	suiteFixtureTeardowns []func(t *SystemTest)
+)
+
+// Get returns the value of the fixture for the scope of t, setting it up first if needed.
+// Time spent waiting for and setting up fixtures is reported separately from the test case duration.
+// Only the outermost Get counts, as the time of fixtures set up by another fixture's Setup is part of it.
+func (f *Fixture[T]) Get(t *SystemTest) T {
+	t.Unwrap.Helper()
+	requestedAt := time.Now()
+	if t.enterFixture() {
+		defer func() {
+			t.addFixtureSetupTime(time.Since(requestedAt))
+		}()
+	}
+	defer t.exitFixture()
+
+	entry := f.store(t).entry(f)
+	entry.mutex.Lock()
+	defer entry.mutex.Unlock()
+
+	if entry.created {
+		return entry.value.(T)
+	}
+
+	t.Logf("Setting up %s fixture [%s]...", f.Scope, f.Name)
+	value := f.Setup(t)
+	entry.value = value
+	entry.created = true
+	t.Logf("%s fixture [%s] set up in [%v]", f.Scope, f.Name, time.Since(requestedAt).Round(time.Millisecond))
+
+	if f.Teardown != nil {
+		teardown := func(teardownTest *SystemTest) {
+			teardownTest.Logf("Tearing down %s fixture [%s]...", f.Scope, f.Name)
+			f.Teardown(teardownTest, value)
+		}
+		switch f.Scope {
+		case SuiteScope:
+			suiteTeardownsMutex.Lock()
+			suiteFixtureTeardowns = append(suiteFixtureTeardowns, func(teardownTest *SystemTest) {
+				log.Printf("Tearing down suite fixture [%s]...", f.Name)
+				f.Teardown(teardownTest, value)
+			})
+			suiteTeardownsMutex.Unlock()
+		case TestScope:
+			root := t.rootTest()
+			root.Cleanup(func() { teardown(root) })
+		default:
+			t.Cleanup(func() { teardown(t) })
+		}
+	}
+
+	return value
+}
+
+func (f *Fixture[T]) store(t *SystemTest) *fixtureStore {
+	switch f.Scope {
+	case SuiteScope:
+		return suiteFixtures
+	case TestScope:
+		return &t.rootTest().fixtures
+	default:
+		return &t.fixtures
+	}
+}
+
+// TeardownSuiteFixtures tears down all suite scoped fixtures in reverse order of creation, logging their output.
+// It is called by Finish once m.Run() returns.
+func TeardownSuiteFixtures() error {
+	suiteTeardownsMutex.Lock()
+	teardowns := suiteFixtureTeardowns
+	suiteFixtureTeardowns = nil
+	suiteTeardownsMutex.Unlock()
+
+	var failures []string
+	for i := len(teardowns) - 1; i >= 0; i-- {
+		report := &teardownReport{}
+		t := &SystemTest{Unwrap: new(testing.T), teardown: report}
+		// run in a separate goroutine, as a failed assertion calls runtime.Goexit
+		done := make(chan struct{})
+		go func(teardown func(t *SystemTest)) {
+			defer close(done)
+			defer func() {
+				if err := recover(); err != nil {
+					report.fail(fmt.Sprintf("suite fixture teardown exited due to panic - [%v], stack: [%v]", err, string(debug.Stack())))
+				}
+			}()
+			teardown(t)
+		}(teardowns[i])
+		<-done
+		failures = append(failures, report.failures...)
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%d suite fixture teardown failures:\n%s", len(failures), strings.Join(failures, "\n"))
+	}
+	return nil
+}
+
+// teardownReport logs the output of a suite fixture teardown and collects its failures, as it runs once
+// all tests have finished, outside of any *testing.T which could report them
+type teardownReport struct {
+	mutex    sync.Mutex
+	failures []string
+}
+
+// log logs the message, returning false if t is not tearing down suite fixtures
+func (r *teardownReport) log(message string) bool {
+	if r == nil {
+		return false
+	}
+	log.Print(message)
+	return true
+}
+
+// fail records the failure, returning false if t is not tearing down suite fixtures
+func (r *teardownReport) fail(message string) bool {
+	if r == nil {
+		return false
+	}
+	log.Printf("[SUITE FIXTURE TEARDOWN FAILED] %s", message)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.failures = append(r.failures, message)
+	return true
+}
+
+func (r *teardownReport) failed() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return len(r.failures) > 0
+}
+
+func (s *SystemTest) rootTest() *SystemTest {
+	if s.root == nil {
+		return s
+	}
+	return s.root
+}
+
+// enterFixture returns true if this is the outermost Get of a fixture running for the test case
+func (s *SystemTest) enterFixture() bool {
+	s.resultMutex.Lock()
+	defer s.resultMutex.Unlock()
+	s.fixtureDepth++
+	return s.fixtureDepth == 1
+}
+
+func (s *SystemTest) exitFixture() {
+	s.resultMutex.Lock()
+	defer s.resultMutex.Unlock()
+	s.fixtureDepth--
+}
+
+func (s *SystemTest) addFixtureSetupTime(d time.Duration) {
+	s.resultMutex.Lock()
+	defer s.resultMutex.Unlock()
+	s.result.FixtureSetup += d.Seconds()
+}
@@ -0,0 +1,59 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNestedFixtureSetupTimeIsCountedOnce(t *testing.T) {
+	inner := &Fixture[int]{Name: "inner", Setup: func(t *SystemTest) int {
+		time.Sleep(50 * time.Millisecond)
+		return 1
+	}}
+	outer := &Fixture[int]{Name: "outer", Setup: func(t *SystemTest) int {
+		return inner.Get(t) + 1
+	}}
+
+	s := NewSystemTest(t)
+	if value := outer.Get(s); value != 2 {
+		t.Fatalf("expected 2, got %d", value)
+	}
+
+	if setup := s.result.FixtureSetup; setup < 0.05 || setup >= 0.1 {
+		t.Errorf("expected fixture setup time of about 50ms, got %vs", setup)
+	}
+}
+
+func TestSuiteFixtureTeardownFailuresAreReported(t *testing.T) {
+	failing := &Fixture[string]{
+		Name:  "failing",
+		Scope: SuiteScope,
+		Setup: func(t *SystemTest) string { return "value" },
+		Teardown: func(t *SystemTest, value string) {
+			t.Logf("tearing down %s", value)
+			t.Fatalf("teardown of %s failed", value)
+		},
+	}
+	passing := &Fixture[string]{
+		Name:     "passing",
+		Scope:    SuiteScope,
+		Setup:    func(t *SystemTest) string { return "value" },
+		Teardown: func(t *SystemTest, value string) {},
+	}
+
+	s := NewSystemTest(t)
+	failing.Get(s)
+	passing.Get(s)
+
+	err := TeardownSuiteFixtures()
+	if err == nil || !strings.Contains(err.Error(), "teardown of value failed") {
+		t.Fatalf("expected the teardown failure to be returned, got %v", err)
+	}
+	if strings.Count(err.Error(), "failed") != 1 {
+		t.Errorf("expected one failure, got %v", err)
+	}
+	if err := TeardownSuiteFixtures(); err != nil {
+		t.Errorf("expected teardowns to run once, got %v", err)
+	}
+}
@@ -0,0 +1,223 @@
+package test
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Names of shared network state guarded by resource locks
+const (
+	StorageSCConfigLock    = "storagesc-config"
+	MinerSCConfigLock      = "minersc-config"
+	FaucetSCConfigLock     = "faucetsc-config"
+	ZCNSCConfigLock        = "zcnsc-config"
+	GlobalConfigLock       = "global-config"
+	BlobberOwnerWalletLock = "blobber-owner-wallet"
+)
+
+// ResourceLock names a piece of shared state a test case depends on.
+// Exclusive locks are taken by test cases mutating the state, shared locks by test cases only reading it.
+type ResourceLock struct {
+	Name   string
+	Shared bool
+}
+
+// Exclusive returns exclusive locks for the given resources
+func Exclusive(names ...string) []ResourceLock {
+	locks := make([]ResourceLock, 0, len(names))
+	for _, name := range names {
+		locks = append(locks, ResourceLock{Name: name})
+	}
+	return locks
+}
+
+// Shared returns shared locks for the given resources
+func Shared(names ...string) []ResourceLock {
+	locks := make([]ResourceLock, 0, len(names))
+	for _, name := range names {
+		locks = append(locks, ResourceLock{Name: name, Shared: true})
+	}
+	return locks
+}
+
+var (
+	resourceLocksMutex sync.Mutex
+	resourceLocks      = make(map[string]*rwLock)
+)
+
+func resourceLock(name string) *rwLock {
+	resourceLocksMutex.Lock()
+	defer resourceLocksMutex.Unlock()
+
+	lock, ok := resourceLocks[name]
+	if !ok {
+		lock = &rwLock{changed: make(chan struct{})}
+		resourceLocks[name] = lock
+	}
+	return lock
+}
+
+// rwLock is a readers-writer lock which can stop waiting once a context is done. Like sync.RWMutex, waiting writers
+// keep new readers out, so writers are not starved by a steady stream of readers.
+type rwLock struct {
+	mutex          sync.Mutex
+	readers        int
+	writer         bool
+	waitingWriters int
+	// changed is closed and replaced whenever the lock is released or a writer stops waiting
+	changed chan struct{}
+}
+
+// acquire waits until the lock is held, returning the error of ctx if it is done first
+func (l *rwLock) acquire(ctx context.Context, shared bool) error {
+	l.mutex.Lock()
+	if !shared {
+		l.waitingWriters++
+	}
+	for {
+		if shared && !l.writer && l.waitingWriters == 0 {
+			l.readers++
+			l.mutex.Unlock()
+			return nil
+		}
+		if !shared && !l.writer && l.readers == 0 {
+			l.waitingWriters--
+			l.writer = true
+			l.mutex.Unlock()
+			return nil
+		}
+
+		changed := l.changed
+		l.mutex.Unlock()
+		select {
+		case <-changed:
+			l.mutex.Lock()
+		case <-ctx.Done():
+			l.mutex.Lock()
+			if !shared {
+				l.waitingWriters--
+				l.broadcast()
+			}
+			l.mutex.Unlock()
+			return ctx.Err()
+		}
+	}
+}
+
+func (l *rwLock) release(shared bool) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if shared {
+		l.readers--
+	} else {
+		l.writer = false
+	}
+	l.broadcast()
+}
+
+func (l *rwLock) broadcast() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
+
+// RunWithLocks runs a test case in parallel once all locks are held. Locks are held until the test case
+// and its cleanup functions have finished, so test cases touching different shared state can run in parallel
+// rather than being serialised with RunSequentially.
+func (s *SystemTest) RunWithLocks(locks []ResourceLock, name string, testCaseFunction func(w *SystemTest)) bool {
+	s.Unwrap.Helper()
+	return s.RunWithLocksAndTimeout(locks, name, DefaultTestTimeout, testCaseFunction)
+}
+
+func (s *SystemTest) RunWithLocksAndTimeout(locks []ResourceLock, name string, timeout time.Duration, testCaseFunction func(w *SystemTest)) bool {
+	s.Unwrap.Helper()
+	s.locksMutex.Lock()
+	s.pendingLocks = append(s.pendingLocks, locks...)
+	s.locksMutex.Unlock()
+	return s.run(name, timeout, testCaseFunction, true)
+}
+
+// HoldLocks acquires locks for the rest of this test and every test case run from it, e.g. a shared lock on
+// a smart contract config read at the start of a test. The locks are released once the test has finished.
+func (s *SystemTest) HoldLocks(locks []ResourceLock) {
+	s.Unwrap.Helper()
+	s.locksMutex.Lock()
+	held := make(map[string]bool, len(s.heldLocks))
+	for name, shared := range s.heldLocks {
+		held[name] = shared
+	}
+	s.locksMutex.Unlock()
+
+	s.Cleanup(s.acquireLocks(s.Context(), locks, held))
+
+	s.locksMutex.Lock()
+	s.heldLocks = held
+	s.locksMutex.Unlock()
+}
+
+// nextCaseLocks returns the locks the next test case run from this test must acquire, and the locks it inherits.
+func (s *SystemTest) nextCaseLocks() (pending []ResourceLock, held map[string]bool) {
+	s.locksMutex.Lock()
+	defer s.locksMutex.Unlock()
+
+	pending = s.pendingLocks
+	s.pendingLocks = nil
+
+	held = make(map[string]bool, len(s.heldLocks))
+	for name, shared := range s.heldLocks {
+		held[name] = shared
+	}
+	return pending, held
+}
+
+// acquireLocks acquires the locks in a stable order to avoid deadlocks between test cases, returning a function releasing them.
+// Locks already held by a parent test case are not acquired again. The test fails if ctx is done before all locks are held.
+func (s *SystemTest) acquireLocks(ctx context.Context, locks []ResourceLock, held map[string]bool) (release func()) {
+	wanted := make(map[string]bool)
+	for _, lock := range locks {
+		shared, ok := wanted[lock.Name]
+		wanted[lock.Name] = lock.Shared && (!ok || shared)
+	}
+
+	names := make([]string, 0, len(wanted))
+	for name := range wanted {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var releases []func()
+	release = func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+
+	for _, name := range names {
+		shared := wanted[name]
+		if heldShared, ok := held[name]; ok {
+			if heldShared && !shared {
+				release()
+				s.Fatalf("Cannot acquire exclusive lock [%s] as a parent test case holds a shared lock on it", name)
+				return func() {}
+			}
+			continue
+		}
+
+		waitingSince := time.Now()
+		lock := resourceLock(name)
+		if err := lock.acquire(ctx, shared); err != nil {
+			release()
+			s.Fatalf("Gave up waiting for lock [%s] after [%v]: %v", name, time.Since(waitingSince).Round(time.Second), err)
+			return func() {}
+		}
+		releases = append(releases, func() { lock.release(shared) })
+		held[name] = shared
+
+		if waited := time.Since(waitingSince); waited > time.Second {
+			s.Logf("Waited [%v] for lock [%s]", waited.Round(time.Second), name)
+		}
+	}
+
+	return release
+}
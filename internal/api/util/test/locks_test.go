@@ -0,0 +1,74 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRWLock(t *testing.T) {
+	lock := &rwLock{changed: make(chan struct{})}
+	ctx := context.Background()
+
+	if err := lock.acquire(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := lock.acquire(ctx, true); err != nil {
+		t.Fatal(err)
+	}
+
+	writer := make(chan error, 1)
+	go func() { writer <- lock.acquire(ctx, false) }()
+	select {
+	case <-writer:
+		t.Fatal("writer acquired the lock while readers hold it")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// a waiting writer keeps new readers out
+	short, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := lock.acquire(short, true); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected reader to give up while a writer waits, got %v", err)
+	}
+
+	lock.release(true)
+	lock.release(true)
+	if err := <-writer; err != nil {
+		t.Fatal(err)
+	}
+
+	// releasing the writer lets waiting readers in
+	reader := make(chan error, 1)
+	go func() { reader <- lock.acquire(ctx, true) }()
+	lock.release(false)
+	if err := <-reader; err != nil {
+		t.Fatal(err)
+	}
+
+	short, cancel = context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	if err := lock.acquire(short, false); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected writer to give up while a reader holds the lock, got %v", err)
+	}
+	if err := lock.acquire(ctx, true); err != nil {
+		t.Fatalf("expected reader to acquire the lock once the writer gave up, got %v", err)
+	}
+}
+
+func TestHoldLocksAreInheritedByCases(t *testing.T) {
+	name := "test-" + t.Name()
+	s := NewSystemTest(t)
+	s.HoldLocks(Shared(name))
+
+	s.RunWithLocks(Shared(name), "inherits shared lock", func(t *SystemTest) {
+		lock := resourceLock(name)
+		lock.mutex.Lock()
+		readers := lock.readers
+		lock.mutex.Unlock()
+		if readers != 1 {
+			t.Errorf("expected the shared lock to be held once, got %d readers", readers)
+		}
+	})
+}
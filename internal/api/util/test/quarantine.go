@@ -0,0 +1,154 @@
+package test
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// QuarantineModeEnv contains name of env variable selecting which test cases are run:
+// "skip" (default) runs healthy cases only and reports quarantined cases as skipped,
+// "include" runs healthy and flaky cases and "only" runs flaky and broken cases only.
+const QuarantineModeEnv = "QUARANTINE_MODE"
+
+// QuarantineRetriesEnv contains name of env variable with the number of times a failed flaky case is retried
+const QuarantineRetriesEnv = "QUARANTINE_RETRIES"
+
+// DefaultQuarantineRetries is used when QuarantineRetriesEnv is not set
+const DefaultQuarantineRetries = 2
+
+// Kinds of quarantined test cases
+const (
+	QuarantineFlaky  = "flaky"
+	QuarantineBroken = "broken"
+)
+
+const (
+	quarantineModeInclude = "include"
+	quarantineModeSkip    = "skip"
+	quarantineModeOnly    = "only"
+)
+
+// quarantine holds the declaration of a quarantined test case and the failure state of its current attempt.
+// Failures of quarantined cases are logged and recorded but never fail the underlying *testing.T.
+type quarantine struct {
+	kind     string
+	issueURL string
+	attempts int
+
+	mutex     sync.Mutex
+	hasFailed bool
+}
+
+// RunFlaky declares a known-flaky test case. It is only run when QUARANTINE_MODE is include or only, failed attempts
+// are retried up to QUARANTINE_RETRIES times and the outcome is reported separately from the pass/fail result of the suite.
+func (s *SystemTest) RunFlaky(name, issueURL string, testCaseFunction func(w *SystemTest)) bool {
+	s.Unwrap.Helper()
+	return s.RunFlakyWithTimeout(name, issueURL, DefaultTestTimeout, testCaseFunction)
+}
+
+func (s *SystemTest) RunFlakyWithTimeout(name, issueURL string, timeout time.Duration, testCaseFunction func(w *SystemTest)) bool {
+	s.Unwrap.Helper()
+	q := &quarantine{kind: QuarantineFlaky, issueURL: issueURL, attempts: quarantineRetries() + 1}
+	return s.runCase(name, timeout, testCaseFunction, true, q)
+}
+
+// RunBroken declares a test case for a known-broken feature. It is only run when QUARANTINE_MODE=only
+// and its outcome never fails the suite.
+func (s *SystemTest) RunBroken(name, issueURL string, testCaseFunction func(w *SystemTest)) bool {
+	s.Unwrap.Helper()
+	return s.RunBrokenWithTimeout(name, issueURL, DefaultTestTimeout, testCaseFunction)
+}
+
+func (s *SystemTest) RunBrokenWithTimeout(name, issueURL string, timeout time.Duration, testCaseFunction func(w *SystemTest)) bool {
+	s.Unwrap.Helper()
+	q := &quarantine{kind: QuarantineBroken, issueURL: issueURL, attempts: 1}
+	return s.runCase(name, timeout, testCaseFunction, true, q)
+}
+
+// nested returns the quarantine for a test case run inside this one, which shares its declaration but is not retried by itself.
+func (q *quarantine) nested() *quarantine {
+	if q == nil {
+		return nil
+	}
+	return &quarantine{kind: q.kind, issueURL: q.issueURL, attempts: 1}
+}
+
+func (q *quarantine) newAttempt() *quarantine {
+	if q == nil {
+		return nil
+	}
+	return &quarantine{kind: q.kind, issueURL: q.issueURL, attempts: q.attempts}
+}
+
+func (q *quarantine) skipReason() string {
+	mode := quarantineMode()
+	switch {
+	case q == nil && mode == quarantineModeOnly:
+		return "Skipping healthy test case as " + QuarantineModeEnv + "=" + quarantineModeOnly
+	case q != nil && mode == quarantineModeSkip:
+		return "Skipping " + q.kind + " test case as " + QuarantineModeEnv + "=" + quarantineModeSkip + ", see " + q.issueURL
+	case q != nil && q.kind == QuarantineBroken && mode != quarantineModeOnly:
+		return "Skipping broken test case, see " + q.issueURL
+	}
+	return ""
+}
+
+// divert records a failure of a quarantined test case instead of failing the test, returning false for healthy cases.
+func (q *quarantine) divert(s *SystemTest, message string) bool {
+	if q == nil {
+		return false
+	}
+	q.fail()
+	s.Unwrap.Logf("[QUARANTINED %s, see %s] %s", strings.ToUpper(q.kind), q.issueURL, message)
+	return true
+}
+
+func (q *quarantine) fail() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.hasFailed = true
+}
+
+func (q *quarantine) failed() bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.hasFailed
+}
+
+func quarantineMode() string {
+	mode := strings.ToLower(strings.TrimSpace(os.Getenv(QuarantineModeEnv)))
+	switch mode {
+	case quarantineModeInclude, quarantineModeOnly:
+		return mode
+	default:
+		return quarantineModeSkip
+	}
+}
+
+func quarantineRetries() int {
+	value, ok := os.LookupEnv(QuarantineRetriesEnv)
+	if !ok {
+		return DefaultQuarantineRetries
+	}
+	retries, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || retries < 0 {
+		log.Printf("%s could not be parsed so has defaulted to [%v]", QuarantineRetriesEnv, DefaultQuarantineRetries)
+		return DefaultQuarantineRetries
+	}
+	return retries
+}
+
+// logQuarantineSummary logs the outcome of every quarantined test case, as these are not part of the pass/fail result.
+func logQuarantineSummary(results []CaseResult) {
+	for _, result := range results {
+		if result.Quarantine == "" {
+			continue
+		}
+		log.Printf("Quarantined %s test case [%s] %s after [%v] attempt(s), see %s",
+			result.Quarantine, result.Name, result.Outcome, result.Attempts, result.IssueURL)
+	}
+}
@@ -0,0 +1,35 @@
+package test
+
+import "testing"
+
+func TestQuarantineSkipReason(t *testing.T) {
+	flaky := &quarantine{kind: QuarantineFlaky, issueURL: "issue"}
+	broken := &quarantine{kind: QuarantineBroken, issueURL: "issue"}
+
+	tests := []struct {
+		mode    string
+		q       *quarantine
+		skipped bool
+	}{
+		{mode: "", q: nil, skipped: false},
+		{mode: "", q: flaky, skipped: true},
+		{mode: "", q: broken, skipped: true},
+		{mode: quarantineModeSkip, q: flaky, skipped: true},
+		{mode: quarantineModeInclude, q: nil, skipped: false},
+		{mode: quarantineModeInclude, q: flaky, skipped: false},
+		{mode: quarantineModeInclude, q: broken, skipped: true},
+		{mode: quarantineModeOnly, q: nil, skipped: true},
+		{mode: quarantineModeOnly, q: flaky, skipped: false},
+		{mode: quarantineModeOnly, q: broken, skipped: false},
+	}
+	for _, tt := range tests {
+		t.Setenv(QuarantineModeEnv, tt.mode)
+		if reason := tt.q.skipReason(); (reason != "") != tt.skipped {
+			kind := "healthy"
+			if tt.q != nil {
+				kind = tt.q.kind
+			}
+			t.Errorf("mode [%s], %s case: expected skipped %v, got reason [%s]", tt.mode, kind, tt.skipped, reason)
+		}
+	}
+}
@@ -0,0 +1,263 @@
+package test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ResultsJSONLPathEnv contains name of env variable pointing to the JSON Lines file test case results are written to.
+// Each test package writes its own file, named after the package, e.g. results.cli_tests.jsonl for results.jsonl.
+const ResultsJSONLPathEnv = "TEST_RESULTS_JSONL"
+
+// ResultsJUnitPathEnv contains name of env variable pointing to the JUnit XML file written by WriteResults.
+// Each test package writes its own file, named after the package, e.g. results.cli_tests.xml for results.xml.
+const ResultsJUnitPathEnv = "TEST_RESULTS_JUNIT"
+
+// Outcomes of a test case
+const (
+	OutcomePassed  = "passed"
+	OutcomeFailed  = "failed"
+	OutcomeTimeout = "timeout"
+	OutcomePanic   = "panic"
+	OutcomeSkipped = "skipped"
+)
+
+// CaseResult is the record of a single test case. FixtureSetup is the part of Duration spent waiting for and setting up fixtures.
+type CaseResult struct {
+	Name         string       `json:"name"`
+	Parent       string       `json:"parent,omitempty"`
+	Scheduled    time.Time    `json:"scheduled"`
+	Started      time.Time    `json:"started"`
+	Exited       time.Time    `json:"exited"`
+	Duration     float64      `json:"duration_seconds"`
+	FixtureSetup float64      `json:"fixture_setup_seconds"`
+	Outcome      string       `json:"outcome"`
+	Retries      int64        `json:"retries"`
+	Attempts     int          `json:"attempts"`
+	Quarantine   string       `json:"quarantine,omitempty"`
+	IssueURL     string       `json:"issue_url,omitempty"`
+	Tags         []string     `json:"tags,omitempty"`
+	Steps        []StepResult `json:"steps,omitempty"`
+	Seed         int64        `json:"seed"`
+}
+
+type resultRecorder struct {
+	mutex     sync.Mutex
+	results   []CaseResult
+	jsonlFile *os.File
+	jsonlOnce sync.Once
+}
+
+var recorder = &resultRecorder{}
+
+func (r *resultRecorder) record(result CaseResult) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.results = append(r.results, result)
+
+	path := packageResultsPath(os.Getenv(ResultsJSONLPathEnv))
+	if path == "" {
+		return
+	}
+
+	// the file is truncated once per run, so results of earlier runs are not duplicated
+	r.jsonlOnce.Do(func() {
+		file, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644) //nolint:gosec
+		if err != nil {
+			log.Printf("Failed to open test results file [%s] due to error: %v", path, err)
+			return
+		}
+		r.jsonlFile = file
+	})
+	if r.jsonlFile == nil {
+		return
+	}
+
+	line, err := json.Marshal(result)
+	if err != nil {
+		log.Printf("Failed to serialise result of test case [%s] due to error: %v", result.Name, err)
+		return
+	}
+	if _, err := r.jsonlFile.Write(append(line, '\n')); err != nil {
+		log.Printf("Failed to write result of test case [%s] due to error: %v", result.Name, err)
+	}
+}
+
+// Results returns a copy of all test case results recorded so far.
+func Results() []CaseResult {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return append([]CaseResult(nil), recorder.results...)
+}
+
+// WriteResults writes the JUnit XML report and flushes the JSON Lines report.
+// It is called by Finish once m.Run() returns.
+func WriteResults() {
+	recorder.mutex.Lock()
+	if recorder.jsonlFile != nil {
+		_ = recorder.jsonlFile.Close()
+		recorder.jsonlFile = nil
+	}
+	recorder.mutex.Unlock()
+
+	logQuarantineSummary(Results())
+
+	path := packageResultsPath(os.Getenv(ResultsJUnitPathEnv))
+	if path == "" {
+		return
+	}
+
+	output, err := xml.MarshalIndent(toJUnit(Results()), "", "  ")
+	if err != nil {
+		log.Printf("Failed to serialise JUnit report due to error: %v", err)
+		return
+	}
+
+	if err := os.WriteFile(path, append([]byte(xml.Header), output...), 0644); err != nil { //nolint:gosec
+		log.Printf("Failed to write JUnit report [%s] due to error: %v", path, err)
+	}
+}
+
+// packageResultsPath names the results file after the test package, as every package runs in its own
+// test binary and would otherwise overwrite the results of the others
+func packageResultsPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	pkg := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe"), ".test")
+	if pkg == "" {
+		return path
+	}
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + pkg + ext
+}
+
+// recordSkip records a test case skipped before it was run, e.g. because of its tags or the quarantine mode
+func recordSkip(name, parent string, tags []string, q *quarantine) {
+	now := time.Now()
+	result := CaseResult{
+		Name:      name,
+		Parent:    parent,
+		Scheduled: now,
+		Started:   now,
+		Exited:    now,
+		Outcome:   OutcomeSkipped,
+		Tags:      tags,
+		Seed:      caseSeed(name),
+	}
+	if q != nil {
+		result.Quarantine = q.kind
+		result.IssueURL = q.issueURL
+	}
+	recorder.record(result)
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Errors   int             `xml:"errors,attr"`
+	Skipped  int             `xml:"skipped,attr"`
+	Time     float64         `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Error     *junitMessage `xml:"error,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	Retries   int64         `xml:"retries,attr,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+func toJUnit(results []CaseResult) junitTestSuites {
+	suitesByName := make(map[string]*junitTestSuite)
+	var suiteNames []string
+
+	for _, result := range results {
+		parent := result.Parent
+		if parent == "" {
+			parent = strings.SplitN(result.Name, "/", 2)[0]
+		}
+		// quarantined cases are reported in their own suite so they never count towards the suite's failures
+		suiteName := parent
+		if result.Quarantine != "" {
+			suiteName = parent + " [quarantined]"
+		}
+
+		suite, ok := suitesByName[suiteName]
+		if !ok {
+			suite = &junitTestSuite{Name: suiteName}
+			suitesByName[suiteName] = suite
+			suiteNames = append(suiteNames, suiteName)
+		}
+
+		testCase := junitTestCase{
+			Name:      strings.TrimPrefix(result.Name, parent+"/"),
+			ClassName: suiteName,
+			Time:      result.Duration,
+			Retries:   result.Retries,
+		}
+
+		switch {
+		case result.Quarantine != "" && result.Outcome != OutcomePassed:
+			testCase.Skipped = &junitMessage{Message: "quarantined " + result.Quarantine + " test case " + result.Outcome + ", see " + result.IssueURL}
+			suite.Skipped++
+		case result.Outcome == OutcomeFailed:
+			testCase.Failure = &junitMessage{Message: "test case failed", Type: result.Outcome}
+			suite.Failures++
+		case result.Outcome == OutcomeTimeout, result.Outcome == OutcomePanic:
+			testCase.Error = &junitMessage{Message: "test case exited with " + result.Outcome, Type: result.Outcome}
+			suite.Errors++
+		case result.Outcome == OutcomeSkipped:
+			testCase.Skipped = &junitMessage{Message: "test case skipped"}
+			suite.Skipped++
+		}
+
+		suite.Tests++
+		suite.Time += result.Duration
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	sort.Strings(suiteNames)
+	report := junitTestSuites{}
+	for _, name := range suiteNames {
+		report.Suites = append(report.Suites, *suitesByName[name])
+	}
+
+	return report
+}
+
+// Finish tears down the suite fixtures and writes the results once m.Run() returns, returning the exit code of
+// the run, which fails if tearing down failed: os.Exit(test.Finish(m.Run()))
+func Finish(exitCode int) int {
+	if err := TeardownSuiteFixtures(); err != nil {
+		log.Printf("Tearing down suite fixtures failed: %v", err)
+		if exitCode == 0 {
+			exitCode = 1
+		}
+	}
+	WriteResults()
+	return exitCode
+}
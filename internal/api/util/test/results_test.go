@@ -0,0 +1,72 @@
+package test
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPackageResultsPath(t *testing.T) {
+	pkg := strings.TrimSuffix(strings.TrimSuffix(filepath.Base(os.Args[0]), ".exe"), ".test")
+
+	if got := packageResultsPath(""); got != "" {
+		t.Errorf("expected no path, got [%s]", got)
+	}
+	if got, want := packageResultsPath("out/results.jsonl"), "out/results."+pkg+".jsonl"; got != want {
+		t.Errorf("expected [%s], got [%s]", want, got)
+	}
+	if got, want := packageResultsPath("results"), "results."+pkg; got != want {
+		t.Errorf("expected [%s], got [%s]", want, got)
+	}
+}
+
+func TestSkippedCasesAreRecorded(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "results.jsonl")
+	t.Setenv(ResultsJSONLPathEnv, path)
+	t.Setenv(SkipTagsEnv, TagSlow)
+
+	// a stale file from an earlier run is truncated
+	stale := packageResultsPath(path)
+	if err := os.WriteFile(stale, []byte("{\"name\":\"stale\"}\n"), 0644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+	recorder = &resultRecorder{}
+	defer func() { recorder = &resultRecorder{} }()
+
+	s := NewSystemTest(t)
+	s.RunSequentially("run", func(t *SystemTest) {})
+	s.Tagged(TagSlow).RunSequentially("excluded", func(t *SystemTest) {
+		t.Error("excluded case should be skipped")
+	})
+	WriteResults()
+
+	file, err := os.Open(stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	outcomes := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var result CaseResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			t.Fatal(err)
+		}
+		outcomes[strings.TrimPrefix(result.Name, t.Name()+"/")] = result.Outcome
+	}
+
+	if len(outcomes) != 2 {
+		t.Fatalf("expected 2 results, got %v", outcomes)
+	}
+	if outcomes["run"] != OutcomePassed {
+		t.Errorf("expected case to pass, got [%s]", outcomes["run"])
+	}
+	if outcomes["excluded"] != OutcomeSkipped {
+		t.Errorf("expected excluded case to be skipped, got [%s]", outcomes["excluded"])
+	}
+}
@@ -0,0 +1,87 @@
+package test
+
+import (
+	"hash/fnv"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// SeedEnv contains name of env variable with the seed of the test run.
+// Every test case derives its own seed from it and its name, so a failing test case can be replayed exactly.
+const SeedEnv = "TEST_SEED"
+
+var (
+	runSeedOnce sync.Once
+	runSeed     int64
+)
+
+// RunSeed returns the seed of the test run, taken from TEST_SEED or picked at random if it is not set.
+func RunSeed() int64 {
+	runSeedOnce.Do(func() {
+		if value := os.Getenv(SeedEnv); value != "" {
+			seed, err := strconv.ParseInt(value, 10, 64)
+			if err == nil {
+				runSeed = seed
+				log.Printf("Using random seed [%d] from %s", runSeed, SeedEnv)
+				return
+			}
+			log.Printf("Ignoring invalid %s [%s] due to error: %v", SeedEnv, value, err)
+		}
+		runSeed = time.Now().UnixNano()
+		log.Printf("Using random seed [%d], set %s=%d to replay this run", runSeed, SeedEnv, runSeed)
+	})
+	return runSeed
+}
+
+// Seed returns the seed of the random source of this test case.
+func (s *SystemTest) Seed() int64 {
+	return caseSeed(s.Unwrap.Name())
+}
+
+// caseSeed derives the seed of a test case from the seed of the run and the name of the test case
+func caseSeed(name string) int64 {
+	hash := fnv.New64a()
+	_, _ = hash.Write([]byte(name))
+	return RunSeed() ^ int64(hash.Sum64())
+}
+
+// Rand returns the random source of this test case. Test inputs such as file names, file contents and sizes
+// should be drawn from it rather than from math/rand or crypto/rand, so they can be replayed by setting TEST_SEED.
+// It is safe for concurrent use.
+func (s *SystemTest) Rand() *rand.Rand {
+	s.randMutex.Lock()
+	defer s.randMutex.Unlock()
+
+	if s.random == nil {
+		s.random = rand.New(&lockedSource{source: rand.NewSource(s.Seed()).(rand.Source64)}) //nolint:gosec
+	}
+	return s.random
+}
+
+// lockedSource guards a rand.Source, as the sources of math/rand are not safe for concurrent use.
+type lockedSource struct {
+	mutex  sync.Mutex
+	source rand.Source64
+}
+
+func (l *lockedSource) Int63() int64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.source.Int63()
+}
+
+func (l *lockedSource) Uint64() uint64 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.source.Uint64()
+}
+
+func (l *lockedSource) Seed(seed int64) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.source.Seed(seed)
+}
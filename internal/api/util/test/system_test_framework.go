@@ -1,11 +1,18 @@
 package test
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"math/rand"
+	"runtime"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"testing"
 	"time"
+
+	"github.com/0chain/system_test/internal/api/util/redact"
 )
 
 var DefaultTestTimeout = 20 * time.Second
@@ -14,10 +21,83 @@ type SystemTest struct {
 	Unwrap       *testing.T
 	testComplete bool
 	childTest    bool
+	ctxMutex     sync.Mutex
+	ctx          context.Context
+	cancel       context.CancelFunc
+	resultMutex  sync.Mutex
+	result       CaseResult
+	timedOut     bool
+	panicked     bool
+	quarantine   *quarantine
+	tagsMutex    sync.Mutex
+	tags         []string
+	pendingTags  []string
+	casesStarted bool
+	locksMutex   sync.Mutex
+	pendingLocks []ResourceLock
+	heldLocks    map[string]bool
+	root         *SystemTest
+	fixtures     fixtureStore
+	fixtureDepth int
+	// teardown is set while tearing down suite fixtures, which run outside of any test
+	teardown *teardownReport
+
+	diagnosticsMutex sync.Mutex
+	diagnostics      diagnostics
+
+	randMutex sync.Mutex
+	random    *rand.Rand
+
+	checksMutex sync.Mutex
+	checks      *Checks
 }
 
 func NewSystemTest(t *testing.T) *SystemTest {
-	return &SystemTest{Unwrap: t, testComplete: false, childTest: false}
+	s := &SystemTest{Unwrap: t, testComplete: false, childTest: false}
+	s.ctx, s.cancel = context.WithCancel(s.withTest(context.Background()))
+	t.Cleanup(s.cancelContext)
+	return s
+}
+
+// Context returns a context which is cancelled once the test case times out or fails fatally.
+// Long-running operations (CLI commands, HTTP requests, polling) should stop once it is done.
+// Cleanup functions are given a fresh context, so resources can still be released after a timeout.
+// The context carries the test case, see FromContext.
+func (s *SystemTest) Context() context.Context {
+	s.ctxMutex.Lock()
+	defer s.ctxMutex.Unlock()
+	if s.ctx == nil {
+		return context.Background()
+	}
+	return s.ctx
+}
+
+type testContextKey struct{}
+
+func (s *SystemTest) withTest(ctx context.Context) context.Context {
+	return context.WithValue(ctx, testContextKey{}, s)
+}
+
+// FromContext returns the test case whose context ctx is, or is derived from, nil if there is none
+func FromContext(ctx context.Context) *SystemTest {
+	s, _ := ctx.Value(testContextKey{}).(*SystemTest)
+	return s
+}
+
+func (s *SystemTest) cancelContext() {
+	s.ctxMutex.Lock()
+	defer s.ctxMutex.Unlock()
+	if s.cancel != nil {
+		s.cancel()
+	}
+}
+
+func (s *SystemTest) renewContextIfDone() {
+	s.ctxMutex.Lock()
+	defer s.ctxMutex.Unlock()
+	if s.ctx != nil && s.ctx.Err() != nil {
+		s.ctx, s.cancel = context.WithCancel(s.withTest(context.Background()))
+	}
 }
 
 func (s *SystemTest) Run(name string, testCaseFunction func(w *SystemTest)) bool {
@@ -42,47 +122,106 @@ func (s *SystemTest) RunSequentiallyWithTimeout(name string, timeout time.Durati
 
 func (s *SystemTest) run(name string, timeout time.Duration, testFunction func(w *SystemTest), runInParallel bool) bool {
 	s.Unwrap.Helper()
+	return s.runCase(name, timeout, testFunction, runInParallel, s.quarantine.nested())
+}
+
+func (s *SystemTest) runCase(name string, timeout time.Duration, testFunction func(w *SystemTest), runInParallel bool, q *quarantine) bool {
+	s.Unwrap.Helper()
+	tags, first := s.nextCaseTags()
+	if !s.childTest && first {
+		// top-level tests which never called Tag are only checked once they run their first case
+		s.skipUnselected(tags...)
+	}
+	locks, heldLocks := s.nextCaseLocks()
 	timeoutWrappedTestCase := func(testSetup *testing.T) {
-		t := &SystemTest{Unwrap: testSetup, testComplete: false, childTest: true}
+		caseSetup := &SystemTest{Unwrap: testSetup, testComplete: false, childTest: true}
 		testSetup.Helper()
-		defer handlePanic(t)
-
-		wg := sync.WaitGroup{}
-		wg.Add(1)
+		defer handlePanic(caseSetup)
 
-		t.Logf("Test case [%s] scheduled at [%s] ", name, time.Now().Format("01-02-2006 15:04:05"))
+		reason := q.skipReason()
+		if reason == "" {
+			reason = tagSkipReason(tags)
+		}
+		if reason != "" {
+			recordSkip(testSetup.Name(), s.Name(), tags, q)
+			caseSetup.Skip(reason)
+		}
 
-		testCaseChannel := make(chan struct{}, 1)
+		scheduledAt := time.Now()
+		caseSetup.Logf("Test case [%s] scheduled at [%s] ", name, scheduledAt.Format("01-02-2006 15:04:05"))
 
 		if runInParallel {
 			if !s.childTest {
-				t.Parallel()
+				caseSetup.Parallel()
 			} else {
-				t.Logf("[WARN] Not running test case [%s] in parallel as it is a child test. Use t.Unwrap.run() then t.Parallel() if you wish to do this.", name)
+				caseSetup.Logf("[WARN] Not running test case [%s] in parallel as it is a child test. Use t.Unwrap.run() then t.Parallel() if you wish to do this.", name)
 			}
 		}
-		go executeTest(t, name, testFunction, testCaseChannel, &wg)
 
-		select {
-		case <-time.After(timeout):
-			t.Errorf("Test case [%s] timed out after [%s]", name, timeout)
-		case _ = <-testCaseChannel:
+		if len(locks) > 0 {
+			testSetup.Cleanup(caseSetup.acquireLocks(s.Context(), locks, heldLocks))
+		}
+
+		var t *SystemTest
+		attempt := 1
+		for ; ; attempt++ {
+			t = &SystemTest{Unwrap: testSetup, testComplete: false, childTest: true, quarantine: q.newAttempt(), tags: tags, heldLocks: heldLocks, root: s.rootTest()}
+			t.ctx, t.cancel = context.WithCancel(t.withTest(s.Context()))
+			t.result = CaseResult{Name: testSetup.Name(), Parent: s.Name(), Scheduled: scheduledAt, Tags: tags}
+			testSetup.Cleanup(t.cancelContext)
+
+			t.execute(name, timeout, testFunction)
+			t.reportChecks("test case [" + name + "]")
+			if t.hasTimedOut() || t.Failed() {
+				t.dumpDiagnostics(name)
+			}
+
+			if q == nil || !t.Failed() || attempt >= q.attempts {
+				break
+			}
+			t.Logf("Quarantined test case [%s] failed on attempt [%v/%v], retrying...", name, attempt, q.attempts)
+			t.testComplete = true
 		}
 
-		t.Logf("Test case [%s] exit at [%s]", name, time.Now().Format("01-02-2006 15:04:05"))
+		exitedAt := time.Now()
+		t.Logf("Test case [%s] exit at [%s]", name, exitedAt.Format("01-02-2006 15:04:05"))
+		t.recordResult(exitedAt, attempt)
+		if s.quarantine != nil && t.Failed() {
+			s.quarantine.fail()
+		}
 		t.testComplete = true
 	}
 
 	return s.Unwrap.Run(name, timeoutWrappedTestCase)
 }
 
+func (s *SystemTest) execute(name string, timeout time.Duration, testFunction func(w *SystemTest)) {
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+
+	testCaseChannel := make(chan struct{}, 1)
+
+	go executeTest(s, name, testFunction, testCaseChannel, &wg)
+
+	select {
+	case <-time.After(timeout):
+		s.Errorf("Test case [%s] timed out after [%s]", name, timeout)
+		s.setTimedOut()
+		s.cancelContext()
+	case _ = <-testCaseChannel:
+	}
+}
+
 func executeTest(s *SystemTest, name string, testFunction func(w *SystemTest), testCaseChannel chan struct{}, wg *sync.WaitGroup) {
 	s.Unwrap.Helper()
 	defer handlePanic(s)
 	go func() {
 		defer wg.Done()
 		defer handlePanic(s)
-		s.Logf("Test case [%s] start at [%s] ", name, time.Now().Format("01-02-2006 15:04:05"))
+		defer s.reportChecks("test case [" + name + "]")
+		startedAt := time.Now()
+		s.setStarted(startedAt)
+		s.Logf("Test case [%s] start at [%s] ", name, startedAt.Format("01-02-2006 15:04:05"))
 		testFunction(s)
 	}()
 	wg.Wait()
@@ -91,10 +230,71 @@ func executeTest(s *SystemTest, name string, testFunction func(w *SystemTest), t
 
 func handlePanic(s *SystemTest) {
 	if err := recover(); err != nil {
+		s.setPanicked()
 		s.Errorf("Test case exited due to panic - [%v], stack: [%v]", err, string(debug.Stack()))
 	}
 }
 
+// RecordCommandRetry counts a retried CLI command or request towards the test case result.
+func (s *SystemTest) RecordCommandRetry() {
+	s.resultMutex.Lock()
+	defer s.resultMutex.Unlock()
+	s.result.Retries++
+}
+
+func (s *SystemTest) setStarted(startedAt time.Time) {
+	s.resultMutex.Lock()
+	defer s.resultMutex.Unlock()
+	s.result.Started = startedAt
+}
+
+func (s *SystemTest) setTimedOut() {
+	s.resultMutex.Lock()
+	defer s.resultMutex.Unlock()
+	s.timedOut = true
+}
+
+func (s *SystemTest) hasTimedOut() bool {
+	s.resultMutex.Lock()
+	defer s.resultMutex.Unlock()
+	return s.timedOut
+}
+
+func (s *SystemTest) setPanicked() {
+	s.resultMutex.Lock()
+	defer s.resultMutex.Unlock()
+	s.panicked = true
+}
+
+func (s *SystemTest) recordResult(exitedAt time.Time, attempts int) {
+	s.resultMutex.Lock()
+	result := s.result
+	result.Exited = exitedAt
+	result.Attempts = attempts
+	result.Steps = s.stepResults()
+	result.Seed = s.Seed()
+	if s.quarantine != nil {
+		result.Quarantine = s.quarantine.kind
+		result.IssueURL = s.quarantine.issueURL
+	}
+	result.Duration = exitedAt.Sub(result.Scheduled).Seconds()
+	switch {
+	case s.timedOut:
+		result.Outcome = OutcomeTimeout
+	case s.panicked:
+		result.Outcome = OutcomePanic
+	case s.Failed():
+		result.Outcome = OutcomeFailed
+	case s.Unwrap.Skipped():
+		result.Outcome = OutcomeSkipped
+	default:
+		result.Outcome = OutcomePassed
+	}
+	s.resultMutex.Unlock()
+
+	recorder.record(result)
+}
+
 func handleTestCaseExit() {
 	if err := recover(); err != nil {
 		log.Printf("Suppressed test function panic - [%v]", err)
@@ -106,14 +306,21 @@ func handleTestCaseExit() {
 func (s *SystemTest) Cleanup(f func()) {
 	s.Unwrap.Helper()
 	defer handleTestCaseExit()
-	s.Unwrap.Cleanup(f)
+	s.Unwrap.Cleanup(func() {
+		s.renewContextIfDone()
+		f()
+	})
 }
 
 func (s *SystemTest) Error(args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Error(args...)
+		message := redactedln(args...)
+		if s.quarantine.divert(s, message) || s.teardown.fail(message) {
+			return
+		}
+		s.Unwrap.Error(message)
 	}
 }
 
@@ -121,7 +328,11 @@ func (s *SystemTest) Errorf(format string, args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Errorf(format, args...)
+		message := redact.String(fmt.Sprintf(format, args...))
+		if s.quarantine.divert(s, message) || s.teardown.fail(message) {
+			return
+		}
+		s.Unwrap.Error(message)
 	}
 }
 
@@ -129,6 +340,9 @@ func (s *SystemTest) Fail() {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
+		if s.quarantine.divert(s, "test case marked as failed") || s.teardown.fail("marked as failed") {
+			return
+		}
 		s.Unwrap.Fail()
 	}
 }
@@ -137,6 +351,17 @@ func (s *SystemTest) FailNow() {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
+		s.cancelContext()
+		if s.quarantine.divert(s, "test case stopped by FailNow") {
+			runtime.Goexit()
+		}
+		if s.teardown != nil {
+			// the failure was recorded by Error or Errorf before
+			if !s.teardown.failed() {
+				s.teardown.fail("stopped by FailNow")
+			}
+			runtime.Goexit()
+		}
 		s.Unwrap.FailNow()
 	}
 }
@@ -144,6 +369,12 @@ func (s *SystemTest) FailNow() {
 func (s *SystemTest) Failed() bool {
 	s.Unwrap.Helper()
 	defer handleTestCaseExit()
+	if s.quarantine != nil {
+		return s.quarantine.failed()
+	}
+	if s.teardown != nil {
+		return s.teardown.failed()
+	}
 	return s.Unwrap.Failed()
 }
 
@@ -151,7 +382,12 @@ func (s *SystemTest) Fatal(args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Fatal(args...)
+		s.cancelContext()
+		message := redactedln(args...)
+		if s.quarantine.divert(s, message) || s.teardown.fail(message) {
+			runtime.Goexit()
+		}
+		s.Unwrap.Fatal(message)
 	}
 }
 
@@ -159,7 +395,12 @@ func (s *SystemTest) Fatalf(format string, args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Fatalf(format, args...)
+		s.cancelContext()
+		message := redact.String(fmt.Sprintf(format, args...))
+		if s.quarantine.divert(s, message) || s.teardown.fail(message) {
+			runtime.Goexit()
+		}
+		s.Unwrap.Fatal(message)
 	}
 }
 
@@ -167,7 +408,11 @@ func (s *SystemTest) Log(args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Log(args...)
+		message := redactedln(args...)
+		if s.teardown.log(message) {
+			return
+		}
+		s.Unwrap.Log(message)
 	}
 }
 
@@ -175,10 +420,19 @@ func (s *SystemTest) Logf(format string, args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Logf(format, args...)
+		message := redact.String(fmt.Sprintf(format, args...))
+		if s.teardown.log(message) {
+			return
+		}
+		s.Unwrap.Log(message)
 	}
 }
 
+// redactedln formats args the way testing.T.Log does, masking secrets
+func redactedln(args ...any) string {
+	return redact.String(strings.TrimSuffix(fmt.Sprintln(args...), "\n"))
+}
+
 func (s *SystemTest) Name() string {
 	s.Unwrap.Helper()
 	defer handleTestCaseExit()
@@ -197,7 +451,7 @@ func (s *SystemTest) Skip(args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Skip(args...)
+		s.Unwrap.Skip(redactedln(args...))
 	}
 }
 
@@ -213,7 +467,7 @@ func (s *SystemTest) Skipf(format string, args ...any) {
 	if !s.testComplete {
 		s.Unwrap.Helper()
 		defer handleTestCaseExit()
-		s.Unwrap.Skipf(format, args...)
+		s.Unwrap.Skip(redact.String(fmt.Sprintf(format, args...)))
 	}
 }
 
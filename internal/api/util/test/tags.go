@@ -0,0 +1,141 @@
+package test
+
+import (
+	"os"
+	"sort"
+	"strings"
+)
+
+// TagsEnv contains name of env variable with a comma separated list of tags.
+// When set, only tests and test cases carrying at least one of these tags are run, so untagged tests are skipped.
+const TagsEnv = "TEST_TAGS"
+
+// SkipTagsEnv contains name of env variable with a comma separated list of tags.
+// Test cases carrying any of these tags are skipped.
+const SkipTagsEnv = "TEST_SKIP_TAGS"
+
+// Commonly used tags
+const (
+	TagSmoke       = "smoke"
+	TagSlow        = "slow"
+	TagBridge      = "bridge"
+	TagTokenomics  = "tokenomics"
+	TagOwnerConfig = "owner-config"
+)
+
+// Tag adds tags to this test and every test case run from it.
+// The test is skipped straight away if one of the tags is excluded by TEST_SKIP_TAGS,
+// or if TEST_TAGS is set and includes none of them.
+func (s *SystemTest) Tag(tags ...string) {
+	s.Unwrap.Helper()
+	s.tagsMutex.Lock()
+	s.tags = mergeTags(s.tags, tags)
+	s.tagsMutex.Unlock()
+
+	s.skipUnselected()
+}
+
+// skipUnselected skips the test if it is not selected by its tags, recording the skip for top-level tests
+// as these have no test case result of their own. The test is not skipped if the tags of the test case it is
+// about to run are selected, so a tagged case of an untagged test still runs.
+func (s *SystemTest) skipUnselected(caseTags ...string) {
+	s.Unwrap.Helper()
+	tags := s.Tags()
+	reason := tagSkipReason(tags)
+	if _, excluded := excludedTag(tags); !excluded && len(caseTags) > 0 && tagSkipReason(caseTags) == "" {
+		reason = ""
+	}
+	if reason != "" {
+		if !s.childTest {
+			recordSkip(s.Name(), "", tags, nil)
+		}
+		s.Skip(reason)
+	}
+}
+
+// Tagged adds tags to the next test case run from this test only, e.g. t.Tagged(test.TagSmoke).Run(...)
+// With TEST_TAGS set, a top-level test which is not tagged itself runs as long as its first test case is selected,
+// so tag the test with Tag if only later cases are.
+func (s *SystemTest) Tagged(tags ...string) *SystemTest {
+	s.tagsMutex.Lock()
+	defer s.tagsMutex.Unlock()
+	s.pendingTags = mergeTags(s.pendingTags, tags)
+	return s
+}
+
+// Tags returns all tags of this test, including the ones inherited from its parents.
+func (s *SystemTest) Tags() []string {
+	s.tagsMutex.Lock()
+	defer s.tagsMutex.Unlock()
+	return append([]string(nil), s.tags...)
+}
+
+// nextCaseTags returns the tags of the next test case run from this test, consuming tags added by Tagged,
+// and whether it is the first test case run from this test.
+func (s *SystemTest) nextCaseTags() (tags []string, first bool) {
+	s.tagsMutex.Lock()
+	defer s.tagsMutex.Unlock()
+	tags = mergeTags(s.tags, s.pendingTags)
+	s.pendingTags = nil
+	first = !s.casesStarted
+	s.casesStarted = true
+	return tags, first
+}
+
+func tagSkipReason(tags []string) string {
+	if tag, excluded := excludedTag(tags); excluded {
+		return "Skipping as tag [" + tag + "] is excluded by " + SkipTagsEnv
+	}
+
+	included := tagsFromEnv(TagsEnv)
+	if len(included) == 0 {
+		return ""
+	}
+	for _, tag := range tags {
+		if _, ok := included[tag]; ok {
+			return ""
+		}
+	}
+	if len(tags) == 0 {
+		return "Skipping untagged test as " + TagsEnv + " is set"
+	}
+	return "Skipping as none of the tags [" + strings.Join(tags, ",") + "] are included by " + TagsEnv
+}
+
+func excludedTag(tags []string) (string, bool) {
+	excluded := tagsFromEnv(SkipTagsEnv)
+	for _, tag := range tags {
+		if _, ok := excluded[tag]; ok {
+			return tag, true
+		}
+	}
+	return "", false
+}
+
+func tagsFromEnv(env string) map[string]struct{} {
+	tags := make(map[string]struct{})
+	for _, tag := range strings.Split(os.Getenv(env), ",") {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			tags[tag] = struct{}{}
+		}
+	}
+	return tags
+}
+
+func mergeTags(tags, newTags []string) []string {
+	unique := make(map[string]struct{}, len(tags)+len(newTags))
+	for _, tag := range append(append([]string(nil), tags...), newTags...) {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag != "" {
+			unique[tag] = struct{}{}
+		}
+	}
+
+	merged := make([]string, 0, len(unique))
+	for tag := range unique {
+		merged = append(merged, tag)
+	}
+	sort.Strings(merged)
+	return merged
+}
@@ -0,0 +1,83 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagsSelectTopLevelTests(t *testing.T) {
+	t.Setenv(TagsEnv, TagSmoke)
+	recorder = &resultRecorder{}
+	defer func() { recorder = &resultRecorder{} }()
+
+	tests := []struct {
+		name string
+		tags []string
+		runs bool
+	}{
+		{name: "untagged", runs: false},
+		{name: "excluded", tags: []string{TagSlow}, runs: false},
+		{name: "included", tags: []string{TagSlow, TagSmoke}, runs: true},
+	}
+	for _, tt := range tests {
+		bodyRan, caseRan := false, false
+		t.Run(tt.name, func(testSetup *testing.T) {
+			s := NewSystemTest(testSetup)
+			if len(tt.tags) > 0 {
+				s.Tag(tt.tags...)
+			}
+			s.RunSequentially("case", func(t *SystemTest) {
+				caseRan = true
+			})
+			bodyRan = true
+		})
+		if bodyRan != tt.runs || caseRan != tt.runs {
+			t.Errorf("%s test: expected run %v, body ran %v, case ran %v", tt.name, tt.runs, bodyRan, caseRan)
+		}
+	}
+
+	skipped := 0
+	for _, result := range Results() {
+		if result.Outcome == OutcomeSkipped && result.Parent == "" {
+			skipped++
+		}
+	}
+	if skipped != 2 {
+		t.Errorf("expected 2 skipped top-level tests to be recorded, got %d", skipped)
+	}
+}
+
+func TestTagsSelectCasesOfUntaggedTests(t *testing.T) {
+	t.Setenv(TagsEnv, TagSmoke)
+	t.Setenv(SkipTagsEnv, TagSlow)
+	recorder = &resultRecorder{}
+	defer func() { recorder = &resultRecorder{} }()
+
+	var ran []string
+	t.Run("selected first case", func(testSetup *testing.T) {
+		s := NewSystemTest(testSetup)
+		s.Tagged(TagSmoke).RunSequentially("smoke", func(t *SystemTest) {
+			ran = append(ran, "smoke")
+		})
+		s.RunSequentially("untagged", func(t *SystemTest) {
+			ran = append(ran, "untagged")
+		})
+		s.Tagged(TagSmoke, TagSlow).RunSequentially("excluded", func(t *SystemTest) {
+			ran = append(ran, "excluded")
+		})
+		ran = append(ran, "body")
+	})
+	t.Run("unselected first case", func(testSetup *testing.T) {
+		s := NewSystemTest(testSetup)
+		s.RunSequentially("untagged", func(t *SystemTest) {
+			ran = append(ran, "unselected untagged")
+		})
+		s.Tagged(TagSmoke).RunSequentially("smoke", func(t *SystemTest) {
+			ran = append(ran, "unselected smoke")
+		})
+	})
+
+	if want := []string{"smoke", "body"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("expected %v to run, got %v", want, ran)
+	}
+}
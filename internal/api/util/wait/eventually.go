@@ -0,0 +1,106 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Condition reports why it is not yet satisfied by returning an error, or nil once it is.
+type Condition func() error
+
+// Backoff returns how long to wait before the given attempt, starting at 1.
+type Backoff func(attempt int) time.Duration
+
+// Constant waits the same period between attempts
+func Constant(period time.Duration) Backoff {
+	return func(int) time.Duration {
+		return period
+	}
+}
+
+// Exponential doubles the period between attempts, starting at initial and capped at maximum
+func Exponential(initial, maximum time.Duration) Backoff {
+	return func(attempt int) time.Duration {
+		period := initial
+		for i := 1; i < attempt && period < maximum; i++ {
+			period *= 2
+		}
+		if period > maximum {
+			return maximum
+		}
+		return period
+	}
+}
+
+// Jitter randomises the period of backoff by up to the given fraction in either direction,
+// so concurrent test cases do not poll the network in lockstep.
+func Jitter(backoff Backoff, fraction float64) Backoff {
+	return func(attempt int) time.Duration {
+		period := float64(backoff(attempt))
+		return time.Duration(period + period*fraction*(2*rand.Float64()-1)) //nolint:gosec
+	}
+}
+
+// Eventually checks the condition until it is satisfied, the timeout elapses or ctx is done.
+// The condition is checked straight away, then after every backoff period, and once more when the timeout elapses.
+// The returned error wraps the last error of the condition.
+func Eventually(ctx context.Context, timeout time.Duration, backoff Backoff, condition Condition) error {
+	deadline := time.Now().Add(timeout)
+
+	for attempt := 1; ; attempt++ {
+		err := condition()
+		if err == nil {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return fmt.Errorf("timed out after [%v] and %d attempt(s) waiting for condition: %w", timeout, attempt, err)
+		}
+		if ctxErr := sleep(ctx, minDuration(backoff(attempt), remaining)); ctxErr != nil {
+			return fmt.Errorf("%v after %d attempt(s) waiting for condition: %w", ctxErr, attempt, err)
+		}
+	}
+}
+
+// Consistently checks the condition stays satisfied for the given duration, returning the first error of the condition.
+// The condition is checked straight away, then after every backoff period, and once more when the duration elapses.
+func Consistently(ctx context.Context, duration time.Duration, backoff Backoff, condition Condition) error {
+	startedAt := time.Now()
+	deadline := startedAt.Add(duration)
+
+	for attempt := 1; ; attempt++ {
+		if err := condition(); err != nil {
+			return fmt.Errorf("condition stopped holding after [%v]: %w", time.Since(startedAt).Round(time.Millisecond), err)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return nil
+		}
+		if err := sleep(ctx, minDuration(backoff(attempt), remaining)); err != nil {
+			return fmt.Errorf("%w while checking condition held for [%v]", err, duration)
+		}
+	}
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,120 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+var errNotYet = errors.New("not yet")
+
+// failUntil returns a condition failing until its given attempt, never succeeding if attempt is 0,
+// and counting the attempts made
+func failUntil(attempt int, attempts *int) Condition {
+	return func() error {
+		*attempts++
+		if attempt == 0 || *attempts < attempt {
+			return errNotYet
+		}
+		return nil
+	}
+}
+
+func cancelled() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	return ctx
+}
+
+func TestEventually(t *testing.T) {
+	tests := map[string]struct {
+		ctx          context.Context
+		succeedAt    int
+		wantAttempts int
+		wantErr      string
+	}{
+		"satisfied straight away":   {ctx: context.Background(), succeedAt: 1, wantAttempts: 1},
+		"satisfied on a later try":  {ctx: context.Background(), succeedAt: 3, wantAttempts: 3},
+		"times out":                 {ctx: context.Background(), wantErr: "timed out after [50ms]"},
+		"stops once ctx is done":    {ctx: cancelled(), wantAttempts: 1, wantErr: "context canceled after 1 attempt(s)"},
+		"checks before ctx is used": {ctx: cancelled(), succeedAt: 1, wantAttempts: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			err := Eventually(tt.ctx, 50*time.Millisecond, Constant(5*time.Millisecond), failUntil(tt.succeedAt, &attempts))
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected condition to be satisfied, got %v", err)
+				}
+			} else {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				if !errors.Is(err, errNotYet) {
+					t.Errorf("expected error to wrap the last error of the condition, got %v", err)
+				}
+			}
+			if tt.wantAttempts > 0 && attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempt(s), got %d", tt.wantAttempts, attempts)
+			}
+			if tt.wantAttempts == 0 && attempts < 5 {
+				t.Errorf("expected the condition to be checked until the timeout, got %d attempt(s)", attempts)
+			}
+		})
+	}
+}
+
+func TestConsistently(t *testing.T) {
+	tests := map[string]struct {
+		ctx          context.Context
+		failAt       int
+		wantAttempts int
+		wantErr      error
+	}{
+		"holds":                  {ctx: context.Background()},
+		"stops holding":          {ctx: context.Background(), failAt: 3, wantAttempts: 3, wantErr: errNotYet},
+		"fails straight away":    {ctx: context.Background(), failAt: 1, wantAttempts: 1, wantErr: errNotYet},
+		"stops once ctx is done": {ctx: cancelled(), wantAttempts: 1, wantErr: context.Canceled},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			condition := func() error {
+				attempts++
+				if attempts == tt.failAt {
+					return errNotYet
+				}
+				return nil
+			}
+			err := Consistently(tt.ctx, 50*time.Millisecond, Constant(5*time.Millisecond), condition)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantAttempts > 0 && attempts != tt.wantAttempts {
+				t.Errorf("expected %d attempt(s), got %d", tt.wantAttempts, attempts)
+			}
+			if tt.wantAttempts == 0 && attempts < 5 {
+				t.Errorf("expected the condition to be checked for the whole duration, got %d attempt(s)", attempts)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	exponential := Exponential(time.Second, 5*time.Second)
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if got := exponential(attempt + 1); got != want {
+			t.Errorf("attempt %d: expected exponential backoff of %v, got %v", attempt+1, want, got)
+		}
+	}
+
+	jittered := Jitter(Constant(time.Second), 0.1)
+	for attempt := 1; attempt <= 20; attempt++ {
+		if got := jittered(attempt); got < 900*time.Millisecond || got > 1100*time.Millisecond {
+			t.Errorf("attempt %d: expected backoff within 10%% of 1s, got %v", attempt, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package wait
 
 import (
+	"errors"
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
@@ -9,22 +10,17 @@ import (
 // PoolImmediately pools passed function for a certain amount of time
 func PoolImmediately(t *test.SystemTest, duration time.Duration, predicate func() bool) {
 	backoffPeriod := time.Second * 2
-	ticker := time.NewTicker(backoffPeriod)
 
-	defer ticker.Stop()
-
-	after := time.After(duration)
-
-	for range ticker.C {
-		select {
-		case <-after:
-			t.Fatal("Timed out waiting for wait condition to pass")
-		default:
-			if predicate() {
-				t.Log("Wait condition has succeed")
-				return
-			}
-			t.Logf("Wait condition failed. Waiting an additional [%v]...", backoffPeriod)
+	err := Eventually(t.Context(), duration, Constant(backoffPeriod), func() error {
+		if predicate() {
+			return nil
 		}
+		t.Logf("Wait condition failed. Waiting an additional [%v]...", backoffPeriod)
+		return errors.New("wait condition is not satisfied")
+	})
+	if err != nil {
+		t.Fatalf("Wait condition did not pass: %v", err)
+		return
 	}
+	t.Log("Wait condition has succeed")
 }
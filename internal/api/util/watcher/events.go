@@ -0,0 +1,55 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// ProviderReward tags the reward paid to a provider in a round
+	ProviderReward = "provider-reward"
+	// DelegateReward tags the reward paid to a delegate pool in a round
+	DelegateReward = "delegate-reward"
+
+	minerScAddress = "6dba10422e368813802877a85039d3985d96760ed844092319743fb3a76712d9"
+	// eventsPageLimit is the largest page the event database returns
+	eventsPageLimit = 20
+)
+
+var eventsClient = &http.Client{Timeout: requestTimeout}
+
+// RewardEvents is an EventSource reading the provider and delegate rewards of a round from the event database
+// of the sharder. The event database may lag behind the finalized blocks, so rewards of the latest rounds can
+// be missing.
+func RewardEvents(ctx context.Context, sharder string, round int64) ([]Event, error) {
+	var events []Event
+	for _, source := range []struct {
+		tag, path, index string
+	}{
+		{tag: ProviderReward, path: "/provider-rewards", index: "provider_id"},
+		{tag: DelegateReward, path: "/delegate-rewards", index: "pool_id"},
+	} {
+		for offset := 0; ; offset += eventsPageLimit {
+			url := fmt.Sprintf("%s/v1/screst/%s%s?start=%d&end=%d&offset=%d&limit=%d",
+				sharder, minerScAddress, source.path, round, round+1, offset, eventsPageLimit)
+			var page []json.RawMessage
+			if err := getJSON(ctx, eventsClient, url, &page); err != nil {
+				return nil, err
+			}
+			for _, data := range page {
+				var ids map[string]interface{}
+				if err := json.Unmarshal(data, &ids); err != nil {
+					return nil, fmt.Errorf("decoding %s event: %w", source.tag, err)
+				}
+				index, _ := ids[source.index].(string)
+				events = append(events, Event{Tag: source.tag, Index: index, Data: data})
+			}
+			if len(page) < eventsPageLimit {
+				break
+			}
+		}
+	}
+	return events, nil
+}
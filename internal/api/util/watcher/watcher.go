@@ -0,0 +1,403 @@
+// Package watcher follows the finalized blocks of the chain and publishes each new round to subscribers,
+// so tests wait on chain progress instead of sleeping for a fixed time.
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+const (
+	defaultPollInterval = time.Second
+	requestTimeout      = 10 * time.Second
+	// recentBlocks is how many published blocks are kept, so a transaction finalized just before
+	// WaitForTxnInBlock is called is still found
+	recentBlocks = 100
+)
+
+// Event is an event recorded for a round by the event database
+type Event struct {
+	// Tag is the kind of event, such as ProviderReward or DelegateReward
+	Tag string `json:"tag"`
+	// Index is the id of the provider the event is about
+	Index string          `json:"index"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// EventSource returns the events of a round from a sharder
+type EventSource func(ctx context.Context, sharder string, round int64) ([]Event, error)
+
+// Block is a finalized block published by the Watcher
+type Block struct {
+	Round        int64                      `json:"round"`
+	Hash         string                     `json:"hash"`
+	MinerID      string                     `json:"miner_id"`
+	CreationDate int64                      `json:"creation_date"`
+	Transactions []*model.TransactionEntity `json:"transactions"`
+	// Events are only set if the watcher has an event source
+	Events []Event `json:"-"`
+}
+
+// HasTransaction reports whether the transaction with the hash is in the block
+func (b *Block) HasTransaction(hash string) bool {
+	for _, txn := range b.Transactions {
+		if txn != nil && txn.Hash == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// Options configures a Watcher
+type Options struct {
+	// PollInterval is how often the latest finalized block is polled, defaults to a second
+	PollInterval time.Duration
+	// FromRound is the first round published, defaults to the latest finalized round when the watcher starts
+	FromRound int64
+	// Events reads the events of each round, none are read if nil
+	Events EventSource
+}
+
+// Watcher polls the latest finalized block from a set of sharders, trying the next sharder when one fails,
+// and publishes every new round in order.
+type Watcher struct {
+	sharders []string
+	opts     Options
+	client   *http.Client
+	cancel   context.CancelFunc
+	done     chan struct{}
+
+	mutex       sync.Mutex
+	sharder     int
+	latest      *Block
+	recent      []*Block
+	subscribers map[*subscription]bool
+	stopped     bool
+	err         error
+	// changed is closed and replaced whenever a block is published
+	changed chan struct{}
+}
+
+type subscription struct {
+	blocks chan *Block
+	done   chan struct{}
+	once   sync.Once
+	// mutex is held while sending, so blocks is never closed during a send
+	mutex sync.Mutex
+}
+
+// close stops sending to the subscription and closes its channel
+func (s *subscription) close() {
+	s.once.Do(func() {
+		close(s.done)
+		s.mutex.Lock()
+		close(s.blocks)
+		s.mutex.Unlock()
+	})
+}
+
+// send delivers the block unless the subscription is closed or ctx is done, returning false if ctx is done
+func (s *subscription) send(ctx context.Context, b *Block) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	select {
+	case <-s.done:
+		return true
+	default:
+	}
+	select {
+	case s.blocks <- b:
+	case <-s.done:
+	case <-ctx.Done():
+		return false
+	}
+	return true
+}
+
+// Start polls the sharders until ctx is done or Stop is called
+func Start(ctx context.Context, sharders []string, opts Options) *Watcher {
+	if opts.PollInterval == 0 {
+		opts.PollInterval = defaultPollInterval
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	w := &Watcher{
+		sharders:    sharders,
+		opts:        opts,
+		client:      &http.Client{Timeout: requestTimeout},
+		cancel:      cancel,
+		done:        make(chan struct{}),
+		subscribers: make(map[*subscription]bool),
+		changed:     make(chan struct{}),
+	}
+	go w.run(ctx)
+	return w
+}
+
+// Watch starts a watcher for the test case, which is stopped when the test case ends
+func Watch(t *test.SystemTest, sharders []string) *Watcher {
+	w := Start(t.Context(), sharders, Options{})
+	t.Cleanup(w.Stop)
+	return w
+}
+
+// Stop stops polling and closes the channels of all subscribers
+func (w *Watcher) Stop() {
+	w.cancel()
+	<-w.done
+}
+
+// Err returns the last error polling the sharders, if the last poll failed
+func (w *Watcher) Err() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.err
+}
+
+// Latest returns the latest published block, nil until the first block is published
+func (w *Watcher) Latest() *Block {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.latest
+}
+
+// Subscribe returns a channel receiving every block published from now on, in order. The watcher waits for
+// subscribers to receive each block, so they must keep receiving until they call unsubscribe. The channel is
+// closed by unsubscribe or when the watcher stops, and is closed right away if the watcher has already stopped.
+func (w *Watcher) Subscribe() (blocks <-chan *Block, unsubscribe func()) {
+	s := &subscription{blocks: make(chan *Block, 1), done: make(chan struct{})}
+	w.mutex.Lock()
+	if w.stopped {
+		w.mutex.Unlock()
+		s.close()
+		return s.blocks, func() {}
+	}
+	w.subscribers[s] = true
+	w.mutex.Unlock()
+
+	return s.blocks, func() {
+		w.mutex.Lock()
+		delete(w.subscribers, s)
+		w.mutex.Unlock()
+		s.close()
+	}
+}
+
+// OnBlock calls f with every block published from now on, until unsubscribe is called
+func (w *Watcher) OnBlock(f func(*Block)) (unsubscribe func()) {
+	blocks, unsubscribe := w.Subscribe()
+	go func() {
+		for block := range blocks {
+			f(block)
+		}
+	}()
+	return unsubscribe
+}
+
+// WaitForRound waits until the round is finalized and returns its block, or the latest block if the round was
+// finalized before.
+func (w *Watcher) WaitForRound(ctx context.Context, round int64) (*Block, error) {
+	return w.waitFor(ctx, fmt.Sprintf("round %d", round), func(b *Block) bool {
+		return b.Round >= round
+	})
+}
+
+// WaitForNRounds waits until n more rounds are finalized, counting from the latest published block, or from
+// the first block published if there is none yet
+func (w *Watcher) WaitForNRounds(ctx context.Context, n int64) (*Block, error) {
+	latest := w.Latest()
+	if latest == nil {
+		var err error
+		if latest, err = w.waitFor(ctx, "first block", func(*Block) bool { return true }); err != nil {
+			return nil, err
+		}
+	}
+	return w.WaitForRound(ctx, latest.Round+n)
+}
+
+// WaitForTxnInBlock waits until the transaction is in a finalized block and returns the block
+func (w *Watcher) WaitForTxnInBlock(ctx context.Context, hash string) (*Block, error) {
+	return w.waitFor(ctx, "transaction "+hash, func(b *Block) bool {
+		return b.HasTransaction(hash)
+	})
+}
+
+// waitFor returns the first block matching, checking the recent blocks before waiting for new ones
+func (w *Watcher) waitFor(ctx context.Context, what string, matches func(*Block) bool) (*Block, error) {
+	var checked int64 = -1
+	for {
+		w.mutex.Lock()
+		for _, b := range w.recent {
+			if b.Round > checked && matches(b) {
+				w.mutex.Unlock()
+				return b, nil
+			}
+		}
+		if w.latest != nil {
+			checked = w.latest.Round
+		}
+		changed := w.changed
+		w.mutex.Unlock()
+
+		select {
+		case <-changed:
+		case <-w.done:
+			return nil, fmt.Errorf("watcher stopped waiting for %s", what)
+		case <-ctx.Done():
+			if pollErr := w.Err(); pollErr != nil {
+				return nil, fmt.Errorf("%w waiting for %s, last poll failed: %v", ctx.Err(), what, pollErr)
+			}
+			return nil, fmt.Errorf("%w waiting for %s", ctx.Err(), what)
+		}
+	}
+}
+
+func (w *Watcher) run(ctx context.Context) {
+	defer func() {
+		w.mutex.Lock()
+		w.stopped = true
+		for s := range w.subscribers {
+			s.close()
+			delete(w.subscribers, s)
+		}
+		w.mutex.Unlock()
+		close(w.done)
+	}()
+
+	next := w.opts.FromRound
+	ticker := time.NewTicker(w.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		var err error
+		next, err = w.poll(ctx, next)
+		w.mutex.Lock()
+		w.err = err
+		w.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll publishes the rounds from next up to the latest finalized round, and returns the next round to publish
+func (w *Watcher) poll(ctx context.Context, next int64) (int64, error) {
+	var latest model.TransactionGetConfirmationResponse
+	if err := w.get(ctx, "/v1/block/get/latest_finalized", &latest); err != nil {
+		return next, err
+	}
+	if next == 0 {
+		next = latest.Round
+	}
+
+	for ; next <= latest.Round; next++ {
+		var res struct {
+			Block *Block `json:"block"`
+		}
+		if err := w.get(ctx, "/v1/block/get?content=full&round="+strconv.FormatInt(next, 10), &res); err != nil {
+			return next, err
+		}
+		if res.Block == nil {
+			return next, fmt.Errorf("no block for round %d", next)
+		}
+		if w.opts.Events != nil {
+			events, err := w.opts.Events(ctx, w.currentSharder(), next)
+			if err != nil {
+				return next, fmt.Errorf("reading events of round %d: %w", next, err)
+			}
+			res.Block.Events = events
+		}
+		if !w.publish(ctx, res.Block) {
+			return next, ctx.Err()
+		}
+	}
+	return next, nil
+}
+
+// publish sends the block to all subscribers, returning false if ctx is done first
+func (w *Watcher) publish(ctx context.Context, b *Block) bool {
+	w.mutex.Lock()
+	w.latest = b
+	w.recent = append(w.recent, b)
+	if len(w.recent) > recentBlocks {
+		w.recent = w.recent[len(w.recent)-recentBlocks:]
+	}
+	close(w.changed)
+	w.changed = make(chan struct{})
+	subscribers := make([]*subscription, 0, len(w.subscribers))
+	for s := range w.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	w.mutex.Unlock()
+
+	for _, s := range subscribers {
+		if !s.send(ctx, b) {
+			return false
+		}
+	}
+	return true
+}
+
+func (w *Watcher) currentSharder() string {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.sharders[w.sharder]
+}
+
+// get reads the path from the current sharder, moving on to the next sharder if it fails
+func (w *Watcher) get(ctx context.Context, path string, result interface{}) error {
+	if len(w.sharders) == 0 {
+		return errors.New("no sharders to watch")
+	}
+
+	var err error
+	for range w.sharders {
+		sharder := w.currentSharder()
+		if err = w.getFrom(ctx, sharder+path, result); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		w.mutex.Lock()
+		w.sharder = (w.sharder + 1) % len(w.sharders)
+		w.mutex.Unlock()
+	}
+	return err
+}
+
+func (w *Watcher) getFrom(ctx context.Context, url string, result interface{}) error {
+	return getJSON(ctx, w.client, url, result)
+}
+
+func getJSON(ctx context.Context, client *http.Client, url string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return fmt.Errorf("reading response of %s: %w", url, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s returned status code %d: %s", url, res.StatusCode, string(body))
+	}
+	return json.Unmarshal(body, result)
+}
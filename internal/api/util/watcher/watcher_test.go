@@ -0,0 +1,279 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/model"
+	"github.com/stretchr/testify/require"
+)
+
+const testPollInterval = 10 * time.Millisecond
+
+// sharder is a fake sharder finalizing rounds up to its latest round, with one transaction and
+// rewardsPerRound provider rewards in every round
+type sharder struct {
+	*httptest.Server
+	latest int64
+	// failing sharders return an error for every request
+	failing         int32
+	rewardsPerRound int
+}
+
+func newSharder(t *testing.T, latest int64) *sharder {
+	s := &sharder{latest: latest}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *sharder) finalize(round int64) {
+	atomic.StoreInt64(&s.latest, round)
+}
+
+func (s *sharder) serve(w http.ResponseWriter, r *http.Request) {
+	if atomic.LoadInt32(&s.failing) != 0 {
+		http.Error(w, "failing", http.StatusServiceUnavailable)
+		return
+	}
+	latest := atomic.LoadInt64(&s.latest)
+	query := r.URL.Query()
+	switch r.URL.Path {
+	case "/v1/block/get/latest_finalized":
+		_ = json.NewEncoder(w).Encode(map[string]int64{"round": latest})
+	case "/v1/block/get":
+		round, _ := strconv.ParseInt(query.Get("round"), 10, 64)
+		if round > latest {
+			http.Error(w, "not finalized", http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]*Block{"block": {
+			Round:        round,
+			Hash:         fmt.Sprintf("block%d", round),
+			Transactions: []*model.TransactionEntity{{Hash: txnHash(round)}},
+		}})
+	case "/v1/screst/" + minerScAddress + "/provider-rewards":
+		start, _ := strconv.Atoi(query.Get("start"))
+		offset, _ := strconv.Atoi(query.Get("offset"))
+		limit, _ := strconv.Atoi(query.Get("limit"))
+		rewards := []map[string]interface{}{}
+		for i := offset; i < s.rewardsPerRound && i < offset+limit; i++ {
+			rewards = append(rewards, map[string]interface{}{"provider_id": fmt.Sprintf("provider%d", i), "block_number": start})
+		}
+		_ = json.NewEncoder(w).Encode(rewards)
+	case "/v1/screst/" + minerScAddress + "/delegate-rewards":
+		_ = json.NewEncoder(w).Encode([]interface{}{})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func txnHash(round int64) string {
+	return fmt.Sprintf("txn%d", round)
+}
+
+func startWatcher(t *testing.T, opts Options, sharders ...*sharder) *Watcher {
+	urls := make([]string, 0, len(sharders))
+	for _, s := range sharders {
+		urls = append(urls, s.URL)
+	}
+	opts.PollInterval = testPollInterval
+	w := Start(context.Background(), urls, opts)
+	t.Cleanup(w.Stop)
+	return w
+}
+
+func receive(t *testing.T, blocks <-chan *Block) *Block {
+	select {
+	case b, ok := <-blocks:
+		require.True(t, ok, "subscription closed")
+		return b
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "no block published")
+		return nil
+	}
+}
+
+func waitContext(t *testing.T) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	t.Cleanup(cancel)
+	return ctx
+}
+
+func TestPublishesRoundsInOrder(t *testing.T) {
+	s := newSharder(t, 0)
+	w := startWatcher(t, Options{FromRound: 1}, s)
+	blocks, unsubscribe := w.Subscribe()
+	defer unsubscribe()
+
+	s.finalize(3)
+	for round := int64(1); round <= 3; round++ {
+		b := receive(t, blocks)
+		require.Equal(t, round, b.Round)
+		require.True(t, b.HasTransaction(txnHash(round)))
+	}
+	s.finalize(5)
+	require.Equal(t, int64(4), receive(t, blocks).Round)
+	require.Equal(t, int64(5), receive(t, blocks).Round)
+	require.Equal(t, int64(5), w.Latest().Round)
+}
+
+func TestStartsFromLatestFinalizedRound(t *testing.T) {
+	s := newSharder(t, 7)
+	w := startWatcher(t, Options{}, s)
+
+	b, err := w.WaitForRound(waitContext(t), 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(7), b.Round, "rounds finalized before the watcher started are not published")
+}
+
+func TestFailsOverToNextSharder(t *testing.T) {
+	failing, healthy := newSharder(t, 10), newSharder(t, 10)
+	failing.failing = 1
+	w := startWatcher(t, Options{}, failing, healthy)
+
+	b, err := w.WaitForRound(waitContext(t), 10)
+	require.NoError(t, err)
+	require.Equal(t, int64(10), b.Round)
+
+	// the watcher stays on the healthy sharder until it fails
+	atomic.StoreInt32(&failing.failing, 0)
+	atomic.StoreInt32(&healthy.failing, 1)
+	failing.finalize(12)
+	b, err = w.WaitForRound(waitContext(t), 12)
+	require.NoError(t, err)
+	require.Equal(t, int64(12), b.Round)
+}
+
+func TestReportsPollErrors(t *testing.T) {
+	s := newSharder(t, 10)
+	s.failing = 1
+	w := startWatcher(t, Options{}, s)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, err := w.WaitForRound(ctx, 10)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Contains(t, err.Error(), "last poll failed")
+	require.Error(t, w.Err())
+}
+
+func TestWaitForTxnInBlock(t *testing.T) {
+	s := newSharder(t, 0)
+	w := startWatcher(t, Options{FromRound: 1}, s)
+	s.finalize(3)
+
+	b, err := w.WaitForTxnInBlock(waitContext(t), txnHash(2))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), b.Round, "transactions in recent blocks are found")
+
+	go s.finalize(6)
+	b, err = w.WaitForTxnInBlock(waitContext(t), txnHash(5))
+	require.NoError(t, err)
+	require.Equal(t, int64(5), b.Round)
+}
+
+func TestWaitForNRounds(t *testing.T) {
+	s := newSharder(t, 0)
+	w := startWatcher(t, Options{FromRound: 1}, s)
+	s.finalize(4)
+	_, err := w.WaitForRound(waitContext(t), 4)
+	require.NoError(t, err)
+
+	go s.finalize(10)
+	b, err := w.WaitForNRounds(waitContext(t), 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(6), b.Round, "rounds are counted from the latest block, not the oldest recent one")
+}
+
+func TestWaitForNRoundsBeforeFirstBlock(t *testing.T) {
+	s := newSharder(t, 0)
+	w := startWatcher(t, Options{FromRound: 3}, s)
+
+	go s.finalize(8)
+	b, err := w.WaitForNRounds(waitContext(t), 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(5), b.Round)
+}
+
+func TestOnBlockStopsAfterUnsubscribe(t *testing.T) {
+	s := newSharder(t, 0)
+	w := startWatcher(t, Options{FromRound: 1}, s)
+
+	rounds := make(chan int64)
+	unsubscribe := w.OnBlock(func(b *Block) { rounds <- b.Round })
+	s.finalize(2)
+	require.Equal(t, int64(1), <-rounds)
+	require.Equal(t, int64(2), <-rounds)
+	unsubscribe()
+
+	s.finalize(4)
+	_, err := w.WaitForRound(waitContext(t), 4)
+	require.NoError(t, err)
+	select {
+	case round := <-rounds:
+		require.Failf(t, "block received after unsubscribe", "round %d", round)
+	case <-time.After(5 * testPollInterval):
+	}
+}
+
+func TestUnsubscribeClosesChannel(t *testing.T) {
+	s := newSharder(t, 0)
+	w := startWatcher(t, Options{FromRound: 1}, s)
+	blocks, unsubscribe := w.Subscribe()
+	s.finalize(5)
+	require.Equal(t, int64(1), receive(t, blocks).Round)
+
+	unsubscribe()
+	unsubscribe()
+	for range blocks {
+	}
+	_, err := w.WaitForRound(waitContext(t), 5)
+	require.NoError(t, err, "the watcher does not wait for unsubscribed subscribers")
+}
+
+func TestStop(t *testing.T) {
+	s := newSharder(t, 0)
+	w := startWatcher(t, Options{FromRound: 1}, s)
+	blocks, unsubscribe := w.Subscribe()
+	defer unsubscribe()
+
+	waiting := make(chan error)
+	go func() {
+		_, err := w.WaitForRound(context.Background(), 100)
+		waiting <- err
+	}()
+	w.Stop()
+	require.ErrorContains(t, <-waiting, "watcher stopped")
+	_, ok := <-blocks
+	require.False(t, ok, "stopping closes the subscriptions")
+
+	blocks, unsubscribe = w.Subscribe()
+	defer unsubscribe()
+	_, ok = <-blocks
+	require.False(t, ok, "subscriptions after stopping are closed")
+	w.Stop()
+}
+
+func TestRewardEvents(t *testing.T) {
+	s := newSharder(t, 0)
+	s.rewardsPerRound = eventsPageLimit + 5
+	w := startWatcher(t, Options{FromRound: 1, Events: RewardEvents}, s)
+	s.finalize(1)
+
+	b, err := w.WaitForRound(waitContext(t), 1)
+	require.NoError(t, err)
+	require.Len(t, b.Events, eventsPageLimit+5, "all pages of rewards are read")
+	for i, event := range b.Events {
+		require.Equal(t, ProviderReward, event.Tag)
+		require.Equal(t, fmt.Sprintf("provider%d", i), event.Index)
+		require.JSONEq(t, fmt.Sprintf(`{"provider_id":"provider%d","block_number":1}`, i), string(event.Data))
+	}
+}
@@ -0,0 +1,90 @@
+package cliclient_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	cliclient "github.com/0chain/system_test/internal/cli/client"
+	"github.com/0chain/system_test/internal/cli/fake/fakecli"
+	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(fakecli.Run(m))
+}
+
+func TestFacade(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	fakecli.RegisterWallet(t, "facade_wallet.json", 2)
+
+	balance, err := cliclient.NewZwallet(fakecli.ConfigFile).GetBalance(t, "facade_wallet.json")
+	require.NoError(t, err)
+	require.Equal(t, cliclient.Balance{ZCN: 2, USD: 0.2}, balance)
+
+	output, err := cliutils.Zbox("newallocation").
+		WithFlags(cliutils.Flags{"lock": 0.5, "size": 10000, "data": 2, "parity": 1}).
+		WithSilent().
+		WithWallet("facade_wallet.json").
+		WithConfig(fakecli.ConfigFile).
+		Run(t, 1, time.Millisecond)
+	require.NoError(t, err, strings.Join(output, "\n"))
+	require.Regexp(t, "^Allocation created: [a-f0-9]{64}$", output[0])
+	allocationID := strings.TrimPrefix(output[0], "Allocation created: ")
+
+	zbox := cliclient.NewZbox(fakecli.ConfigFile, "facade_wallet.json")
+	zbox.Retry = cliclient.Retry{Backoff: time.Millisecond}
+	allocation, err := zbox.GetAllocation(t, allocationID)
+	require.NoError(t, err)
+	require.Equal(t, allocationID, allocation.ID)
+	require.Len(t, allocation.Blobbers, 3)
+
+	blobbers, err := zbox.ListBlobbers(t)
+	require.NoError(t, err)
+	require.NotEmpty(t, blobbers)
+
+	localPath := filepath.Join(t.TempDir(), "file with spaces.txt")
+	require.NoError(t, os.WriteFile(localPath, []byte("offline"), 0600))
+	output, err = cliutils.Zbox("upload").
+		WithFlags(cliutils.Flags{"allocation": allocationID, "localpath": localPath, "remotepath": "/dir/"}).
+		WithSilent().
+		WithWallet("facade_wallet.json").
+		WithConfig(fakecli.ConfigFile).
+		Run(t, 1, time.Millisecond)
+	require.NoError(t, err, strings.Join(output, "\n"))
+	require.Len(t, output, 2)
+	require.Contains(t, output[1], "Status completed callback")
+
+	files, err := zbox.ListFiles(t, allocationID, "/dir")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	require.Equal(t, "/dir/file with spaces.txt", files[0].Path)
+	require.EqualValues(t, 7, files[0].Size)
+
+	allFiles, err := zbox.ListAllFiles(t, allocationID)
+	require.NoError(t, err)
+	require.Len(t, allFiles, 2)
+
+	stats, err := zbox.FileStats(t, allocationID, "/dir/file with spaces.txt")
+	require.NoError(t, err)
+	require.Len(t, stats, 3)
+
+	_, err = zbox.GetAllocation(t, "123abc")
+	var commandErr *cliclient.CommandError
+	require.ErrorAs(t, err, &commandErr)
+	require.NotZero(t, commandErr.ExitCode)
+
+	t.RunSequentially("Transient failures are retried", func(t *test.SystemTest) {
+		t.Setenv("FAKE_CLI_FAILURES", "getbalance:2:consensus not reached")
+
+		zwallet := cliclient.NewZwallet(fakecli.ConfigFile)
+		zwallet.Retry = cliclient.Retry{Backoff: time.Millisecond}
+		balance, err := zwallet.GetBalance(t, "facade_wallet.json")
+		require.NoError(t, err)
+		require.Equal(t, cliclient.Balance{ZCN: 1.5, USD: 0.15}, balance)
+	})
+}
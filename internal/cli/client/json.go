@@ -0,0 +1,102 @@
+package cliclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	cliutils "github.com/0chain/system_test/internal/cli/util"
+)
+
+// maxErrorOutput is the number of characters of command output included in errors
+const maxErrorOutput = 2000
+
+// CommandError is returned when a command exits with an error
+type CommandError struct {
+	Command  string
+	ExitCode int
+	Output   string
+	Err      error
+}
+
+func (e *CommandError) Error() string {
+	return fmt.Sprintf("command [%s] failed with exit code [%d]: %v\noutput: %s", e.Command, e.ExitCode, e.Err, truncate(e.Output))
+}
+
+func (e *CommandError) Unwrap() error {
+	return e.Err
+}
+
+// ParseError is returned when no JSON payload of the expected type is found in the output of a command
+type ParseError struct {
+	Command string
+	Type    string
+	Output  string
+	Err     error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("no %s found in JSON output of command [%s]: %v\noutput: %s", e.Type, e.Command, e.Err, truncate(e.Output))
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// decodeResult decodes the JSON payload written to stdout by a command, falling back to its combined output.
+func decodeResult(result *cliutils.CommandResult, err error, value interface{}) error {
+	if err != nil {
+		return &CommandError{Command: result.Command, ExitCode: result.ExitCode, Output: string(result.Combined), Err: err}
+	}
+
+	decodeErr := DecodeJSON(result.Stdout, value)
+	if decodeErr != nil && !bytes.Equal(result.Stdout, result.Combined) {
+		decodeErr = DecodeJSON(result.Combined, value)
+	}
+	if decodeErr != nil {
+		return &ParseError{Command: result.Command, Type: reflect.TypeOf(value).Elem().String(), Output: string(result.Combined), Err: decodeErr}
+	}
+	return nil
+}
+
+// DecodeJSON decodes the JSON payload found in output mixed with log lines, progress and warnings.
+// The payload is the last line starting with '{' or '[' which, together with the lines following it, is valid JSON.
+func DecodeJSON(output []byte, value interface{}) error {
+	lines := strings.Split(strings.ReplaceAll(string(output), "\r\n", "\n"), "\n")
+
+	var lastErr error
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if !strings.HasPrefix(line, "{") && !strings.HasPrefix(line, "[") {
+			continue
+		}
+
+		// a payload spanning several lines, e.g. indented JSON, followed by nothing but whitespace
+		err := json.Unmarshal([]byte(strings.Join(lines[i:], "\n")), value)
+		if err == nil {
+			return nil
+		}
+		// a single line payload followed by other output
+		if lineErr := json.Unmarshal([]byte(line), value); lineErr == nil {
+			return nil
+		}
+		if lastErr == nil {
+			lastErr = err
+		}
+	}
+
+	if lastErr == nil {
+		return fmt.Errorf("output contains no JSON")
+	}
+	return lastErr
+}
+
+func truncate(output string) string {
+	output = strings.TrimSpace(output)
+	if len(output) > maxErrorOutput {
+		return output[:maxErrorOutput] + "... (truncated)"
+	}
+	return output
+}
@@ -0,0 +1,33 @@
+package cliclient
+
+import (
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	cliutils "github.com/0chain/system_test/internal/cli/util"
+)
+
+const (
+	defaultMaxAttempts = 3
+	defaultBackoff     = 2 * time.Second
+)
+
+// Retry configures how failed commands are retried, see cliutils.RunCommand.
+// Commands are recorded on the test case and replayed from cassettes like any other command.
+type Retry struct {
+	// MaxAttempts defaults to 3
+	MaxAttempts int
+	// Backoff is the wait after the first failed attempt, defaults to 2 seconds
+	Backoff time.Duration
+}
+
+func (r Retry) execute(t *test.SystemTest, cmd *cliutils.Command) (*cliutils.CommandResult, error) {
+	maxAttempts, backoff := r.MaxAttempts, r.Backoff
+	if maxAttempts == 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	if backoff == 0 {
+		backoff = defaultBackoff
+	}
+	return cmd.ExecuteWithRetry(t, maxAttempts, backoff)
+}
@@ -0,0 +1,88 @@
+package cliclient
+
+import (
+	"github.com/0chain/system_test/internal/api/util/test"
+	climodel "github.com/0chain/system_test/internal/cli/model"
+	cliutils "github.com/0chain/system_test/internal/cli/util"
+)
+
+// Zbox runs zbox commands with JSON output as the given wallet, returning typed results.
+type Zbox struct {
+	Config string
+	Wallet string
+	// ConfigDir defaults to ./config
+	ConfigDir string
+	Retry
+}
+
+// NewZbox returns a zbox facade using the config file and the wallet file, both relative to ./config
+func NewZbox(config, wallet string) *Zbox {
+	return &Zbox{Config: config, Wallet: wallet}
+}
+
+// NewZboxInWorkspace returns a zbox facade using the config dir, config file and wallet file of the workspace
+func NewZboxInWorkspace(w *cliutils.Workspace) *Zbox {
+	return &Zbox{Config: w.Config, Wallet: w.Wallet, ConfigDir: w.Dir}
+}
+
+// ForWallet returns a copy of z running commands as another wallet
+func (z *Zbox) ForWallet(wallet string) *Zbox {
+	return &Zbox{Config: z.Config, Wallet: wallet, ConfigDir: z.ConfigDir, Retry: z.Retry}
+}
+
+func (z *Zbox) command(subcommand string) *cliutils.Command {
+	cmd := cliutils.Zbox(subcommand).
+		WithSilent().
+		WithWallet(z.Wallet).
+		WithConfig(z.Config)
+	if z.ConfigDir != "" {
+		cmd.WithConfigDir(z.ConfigDir)
+	}
+	return cmd
+}
+
+func (z *Zbox) ListBlobbers(t *test.SystemTest) ([]climodel.BlobberDetails, error) {
+	var blobbers []climodel.BlobberDetails
+	result, err := z.execute(t, z.command("ls-blobbers").WithFlag("json", nil))
+	return blobbers, decodeResult(result, err, &blobbers)
+}
+
+func (z *Zbox) GetAllocation(t *test.SystemTest, allocationID string) (climodel.Allocation, error) {
+	var allocation climodel.Allocation
+	result, err := z.execute(t, z.command("getallocation").WithFlag("json", nil).WithFlag("allocation", allocationID))
+	return allocation, decodeResult(result, err, &allocation)
+}
+
+func (z *Zbox) GetWallet(t *test.SystemTest) (climodel.Wallet, error) {
+	var wallet climodel.Wallet
+	result, err := z.execute(t, z.command("getwallet").WithFlag("json", nil))
+	return wallet, decodeResult(result, err, &wallet)
+}
+
+func (z *Zbox) ReadPoolInfo(t *test.SystemTest) (climodel.ReadPoolInfo, error) {
+	var readPool climodel.ReadPoolInfo
+	result, err := z.execute(t, z.command("rp-info").WithFlag("json", nil))
+	return readPool, decodeResult(result, err, &readPool)
+}
+
+// FileStats returns the stats of the file by blobber ID
+func (z *Zbox) FileStats(t *test.SystemTest, allocationID, remotePath string) (map[string]climodel.FileStats, error) {
+	var stats map[string]climodel.FileStats
+	result, err := z.execute(t, z.command("stats").WithFlag("json", nil).
+		WithFlags(cliutils.Flags{"allocation": allocationID, "remotepath": remotePath}))
+	return stats, decodeResult(result, err, &stats)
+}
+
+func (z *Zbox) ListFiles(t *test.SystemTest, allocationID, remotePath string) ([]climodel.ListFileResult, error) {
+	var files []climodel.ListFileResult
+	result, err := z.execute(t, z.command("list").WithFlag("json", nil).
+		WithFlags(cliutils.Flags{"allocation": allocationID, "remotepath": remotePath}))
+	return files, decodeResult(result, err, &files)
+}
+
+// ListAllFiles lists all files of the allocation, list-all always writes JSON
+func (z *Zbox) ListAllFiles(t *test.SystemTest, allocationID string) ([]climodel.AllocationFile, error) {
+	var files []climodel.AllocationFile
+	result, err := z.execute(t, z.command("list-all").WithFlag("allocation", allocationID))
+	return files, decodeResult(result, err, &files)
+}
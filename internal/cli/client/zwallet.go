@@ -0,0 +1,88 @@
+package cliclient
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	climodel "github.com/0chain/system_test/internal/cli/model"
+	cliutils "github.com/0chain/system_test/internal/cli/util"
+)
+
+// Zwallet runs zwallet commands as the wallet passed to each method, returning typed results.
+type Zwallet struct {
+	Config string
+	// ConfigDir defaults to ./config
+	ConfigDir string
+	Retry
+}
+
+// NewZwallet returns a zwallet facade using the config file, relative to ./config
+func NewZwallet(config string) *Zwallet {
+	return &Zwallet{Config: config}
+}
+
+// NewZwalletInWorkspace returns a zwallet facade using the config dir and config file of the workspace
+func NewZwalletInWorkspace(w *cliutils.Workspace) *Zwallet {
+	return &Zwallet{Config: w.Config, ConfigDir: w.Dir}
+}
+
+// Balance of a wallet, in ZCN and its USD value
+type Balance struct {
+	ZCN float64
+	USD float64
+}
+
+var (
+	balanceRegex = regexp.MustCompile(`Balance: ([0-9.]+) (SAS|uZCN|mZCN|ZCN) \(([0-9.]+) USD\)`)
+	zcnUnits     = map[string]float64{"SAS": 1e-10, "uZCN": 1e-6, "mZCN": 1e-3, "ZCN": 1}
+)
+
+func (z *Zwallet) command(subcommand, wallet string) *cliutils.Command {
+	cmd := cliutils.Zwallet(subcommand).
+		WithSilent().
+		WithWallet(wallet).
+		WithConfig(z.Config)
+	if z.ConfigDir != "" {
+		cmd.WithConfigDir(z.ConfigDir)
+	}
+	return cmd
+}
+
+// GetBalance returns the balance of the wallet file. getbalance has no JSON output, so its summary line is parsed.
+func (z *Zwallet) GetBalance(t *test.SystemTest, wallet string) (Balance, error) {
+	result, err := z.execute(t, z.command("getbalance", wallet))
+	if err != nil {
+		return Balance{}, &CommandError{Command: result.Command, ExitCode: result.ExitCode, Output: string(result.Combined), Err: err}
+	}
+
+	match := balanceRegex.FindStringSubmatch(string(result.Combined))
+	if match == nil {
+		return Balance{}, &ParseError{Command: result.Command, Type: "balance", Output: string(result.Combined), Err: fmt.Errorf("output does not match %v", balanceRegex)}
+	}
+
+	amount, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return Balance{}, &ParseError{Command: result.Command, Type: "balance", Output: string(result.Combined), Err: err}
+	}
+	usd, err := strconv.ParseFloat(match[3], 64)
+	if err != nil {
+		return Balance{}, &ParseError{Command: result.Command, Type: "balance", Output: string(result.Combined), Err: err}
+	}
+
+	return Balance{ZCN: amount * zcnUnits[match[2]], USD: usd}, nil
+}
+
+func (z *Zwallet) ListMiners(t *test.SystemTest, wallet string) (climodel.NodeList, error) {
+	var miners climodel.NodeList
+	result, err := z.execute(t, z.command("ls-miners", wallet).WithFlags(cliutils.Flags{"active": nil, "json": nil}))
+	return miners, decodeResult(result, err, &miners)
+}
+
+// ListSharders returns the active sharders of the magic block by ID
+func (z *Zwallet) ListSharders(t *test.SystemTest, wallet string) (map[string]climodel.Sharder, error) {
+	var sharders map[string]climodel.Sharder
+	result, err := z.execute(t, z.command("ls-sharders", wallet).WithFlags(cliutils.Flags{"active": nil, "json": nil}))
+	return sharders, decodeResult(result, err, &sharders)
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	stateFile = "fake_chain.json"
+	lockFile  = "fake_chain.lock"
+
+	lockTimeout = 30 * time.Second
+
+	sasPerZCN = 1e10
+	// zcnPrice is the USD value of a token reported by getbalance and rp-info
+	zcnPrice = 0.1
+)
+
+// chain is the ledger and file store shared by every invocation with the same config dir.
+// Each invocation is its own process, so the state is read from the config dir and written back under a lock file.
+type chain struct {
+	Accounts     map[string]*account    `json:"accounts"`
+	Allocations  map[string]*allocation `json:"allocations"`
+	Transactions map[string]string      `json:"transactions"`
+	// Invocations counts how often each subcommand ran with each wallet, deciding when injected failures stop
+	Invocations map[string]int `json:"invocations"`
+}
+
+type account struct {
+	Balance  int64 `json:"balance"`
+	Nonce    int64 `json:"nonce"`
+	ReadPool int64 `json:"read_pool"`
+}
+
+type allocation struct {
+	ID         string           `json:"id"`
+	Owner      string           `json:"owner"`
+	OwnerKey   string           `json:"owner_key"`
+	Size       int64            `json:"size"`
+	Data       int              `json:"data"`
+	Parity     int              `json:"parity"`
+	Expiration int64            `json:"expiration"`
+	WritePool  int64            `json:"write_pool"`
+	Canceled   bool             `json:"canceled"`
+	Files      map[string]*file `json:"files"`
+}
+
+type file struct {
+	Content   []byte `json:"content"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// withChain runs fn with the state of the config dir, saving the state once it returns
+func withChain(configDir string, fn func(c *chain) error) error {
+	if err := os.MkdirAll(configDir, 0755); err != nil { //nolint:gosec
+		return err
+	}
+
+	unlock, err := lock(filepath.Join(configDir, lockFile))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	c, err := loadChain(filepath.Join(configDir, stateFile))
+	if err != nil {
+		return err
+	}
+
+	fnErr := fn(c)
+
+	// invocations are counted even when the command fails, so injected failures run out
+	content, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(configDir, stateFile), content, 0644); err != nil { //nolint:gosec
+		return err
+	}
+	return fnErr
+}
+
+// lock creates the lock file, waiting for concurrent invocations to remove it
+func lock(path string) (func(), error) {
+	deadline := time.Now().Add(lockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644) //nolint:gosec
+		if err == nil {
+			_ = f.Close()
+			return func() { _ = os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for lock file [%s], remove it if no command is running", path)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func loadChain(path string) (*chain, error) {
+	c := &chain{
+		Accounts:     make(map[string]*account),
+		Allocations:  make(map[string]*allocation),
+		Transactions: make(map[string]string),
+		Invocations:  make(map[string]int),
+	}
+
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(content, c); err != nil {
+		return nil, fmt.Errorf("corrupt fake chain state [%s]: %v", path, err)
+	}
+	return c, nil
+}
+
+// injectedFailure counts the invocation and returns the failure injected by FAKE_CLI_FAILURES, if any is left
+func (c *chain) injectedFailure(subcommand, wallet string) error {
+	key := subcommand + " " + wallet
+	c.Invocations[key]++
+
+	for _, spec := range strings.Split(os.Getenv(failuresEnv), ";") {
+		parts := strings.SplitN(spec, ":", 3)
+		if len(parts) != 3 || parts[0] != subcommand {
+			continue
+		}
+		count, err := strconv.Atoi(parts[1])
+		if err != nil {
+			return fmt.Errorf("invalid %s entry [%s]: %v", failuresEnv, spec, err)
+		}
+		if c.Invocations[key] <= count {
+			return errors.New(parts[2])
+		}
+	}
+	return nil
+}
+
+func (c *chain) account(clientID string) *account {
+	a, ok := c.Accounts[clientID]
+	if !ok {
+		a = &account{}
+		c.Accounts[clientID] = a
+	}
+	return a
+}
+
+// transaction records a confirmed transaction of the given type, returning its hash
+func (c *chain) transaction(kind string) string {
+	hash := randomHash()
+	c.Transactions[hash] = kind
+	return hash
+}
+
+func randomHash() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// fixedHash derives a stable ID, e.g. of the fake blobbers
+func fixedHash(name string) string {
+	sum := sha256.Sum256([]byte(name))
+	return hex.EncodeToString(sum[:])
+}
+
+func zcnToSAS(zcn float64) int64 {
+	return int64(math.Round(zcn * sasPerZCN))
+}
+
+func sasToZCN(sas int64) float64 {
+	return float64(sas) / sasPerZCN
+}
+
+// formatBalance formats SAS in the largest unit below the amount, as zwallet getbalance does
+func formatBalance(sas int64) string {
+	switch {
+	case sas == 0:
+		return "0 SAS"
+	case sas >= sasPerZCN:
+		return fmt.Sprintf("%.3f ZCN", sasToZCN(sas))
+	case sas >= sasPerZCN/1e3:
+		return fmt.Sprintf("%.3f mZCN", sasToZCN(sas)*1e3)
+	case sas >= sasPerZCN/1e6:
+		return fmt.Sprintf("%.3f uZCN", sasToZCN(sas)*1e6)
+	default:
+		return fmt.Sprintf("%d SAS", sas)
+	}
+}
+
+// walletFile has the fields of the wallet files written by the real binaries, the keys are random
+type walletFile struct {
+	ClientID    string      `json:"client_id"`
+	ClientKey   string      `json:"client_key"`
+	Keys        []walletKey `json:"keys"`
+	Mnemonics   string      `json:"mnemonics"`
+	Version     string      `json:"version"`
+	DateCreated string      `json:"date_created"`
+}
+
+type walletKey struct {
+	PublicKey  string `json:"public_key"`
+	PrivateKey string `json:"private_key"`
+}
+
+func loadOrCreateWallet(path string) (*walletFile, error) {
+	var w walletFile
+	content, err := os.ReadFile(path)
+	if err == nil {
+		if err := json.Unmarshal(content, &w); err != nil {
+			return nil, fmt.Errorf("invalid wallet file [%s]: %v", path, err)
+		}
+		return &w, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	publicKey := randomHash() + randomHash()
+	w = walletFile{
+		ClientID:    fixedHash(publicKey),
+		ClientKey:   publicKey,
+		Keys:        []walletKey{{PublicKey: publicKey, PrivateKey: randomHash()}},
+		Mnemonics:   "fake wallet of the offline stand-in binaries",
+		Version:     "1.0",
+		DateCreated: time.Now().Format(time.RFC3339),
+	}
+	content, err = json.Marshal(w)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil { //nolint:gosec
+		return nil, err
+	}
+	return &w, nil
+}
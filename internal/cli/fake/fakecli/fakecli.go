@@ -0,0 +1,72 @@
+// Package fakecli runs the tests of a package against the fake zbox and zwallet binaries built from
+// internal/cli/fake, so CLI helpers are tested without a network.
+package fakecli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+// ConfigFile is the config file the fake binaries read from ./config
+const ConfigFile = "config.yaml"
+
+// Run builds the fake zbox and zwallet binaries into a temporary working dir, as the helpers run ./zbox and
+// ./zwallet, and runs the tests there. It returns the exit code for TestMain.
+func Run(m *testing.M) int {
+	dir, err := os.MkdirTemp("", "fake-cli")
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer os.RemoveAll(dir)
+
+	for _, binary := range []string{"zbox", "zwallet"} {
+		if runtime.GOOS == "windows" {
+			binary += ".exe"
+		}
+		build := exec.Command("go", "build", "-o", filepath.Join(dir, binary), "github.com/0chain/system_test/internal/cli/fake")
+		if output, err := build.CombinedOutput(); err != nil {
+			fmt.Printf("Failed to build fake %s: %v\n%s", binary, err, output)
+			return 1
+		}
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	if err := os.Chdir(dir); err != nil {
+		fmt.Println(err)
+		return 1
+	}
+	defer os.Chdir(wd) //nolint:errcheck
+
+	return m.Run()
+}
+
+// RegisterWallet registers the wallet with the fake binaries and pours the tokens into it
+func RegisterWallet(t *test.SystemTest, wallet string, tokens float64) {
+	output := run(t, "./zbox", "register", "--silent", "--wallet", wallet)
+	require.Equal(t, "Wallet registered", output[len(output)-1])
+
+	output = run(t, "./zwallet", "faucet", "--methodName", "pour", "--tokens", strconv.FormatFloat(tokens, 'f', -1, 64),
+		"--input", "{}", "--silent", "--wallet", wallet)
+	require.Regexp(t, "^Execute faucet smart contract success with txn : {2}[a-f0-9]{64}$", output[0])
+}
+
+func run(t *test.SystemTest, binary string, args ...string) []string {
+	args = append(args, "--configDir", "./config", "--config", ConfigFile)
+	output, err := exec.Command(binary, args...).CombinedOutput() //nolint:gosec
+	require.NoError(t, err, string(output))
+	return strings.Split(strings.TrimSpace(string(output)), "\n")
+}
@@ -0,0 +1,230 @@
+// Command fake stands in for the zbox and zwallet binaries. It implements the subset of subcommands and flags
+// used by the test suites against a ledger and file store kept in the config dir, and writes output in the
+// same formats, so the helpers running commands and parsing their output can be tested without a network:
+//
+//	go build -o zbox ./internal/cli/fake && go build -o zwallet ./internal/cli/fake
+//
+// The name of the binary decides whether it acts as zbox or zwallet.
+// Set FAKE_CLI_FAILURES to fail subcommands the first few times they run with a wallet, e.g. "faucet:2:consensus not reached".
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// failuresEnv contains name of env variable listing injected failures as subcommand:count:message, separated by ';'
+const failuresEnv = "FAKE_CLI_FAILURES"
+
+// boolFlags never take the next argument as their value
+var boolFlags = map[string]bool{
+	"silent":  true,
+	"json":    true,
+	"active":  true,
+	"all":     true,
+	"commit":  true,
+	"encrypt": true,
+}
+
+// fakeVersion is reported by the version subcommand
+const fakeVersion = "v1.8.0-fake"
+
+// globalFlags are accepted by every subcommand
+var globalFlags = []string{"config", "configDir", "silent", "wallet"}
+
+// command is a subcommand of a fake binary, with the flags listed by its help output
+type command struct {
+	run   func(inv *invocation) error
+	flags []string
+}
+
+var binaries = map[string]map[string]command{
+	"zbox":    zboxCommands,
+	"zwallet": zwalletCommands,
+}
+
+// invocation is a parsed command line, with the chain state loaded from its config dir
+type invocation struct {
+	subcommand string
+	flags      map[string]string
+	configDir  string
+	chain      *chain
+}
+
+func main() {
+	if err := run(os.Args); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+func run(argv []string) error {
+	binary := strings.TrimSuffix(filepath.Base(argv[0]), ".exe")
+	commands, ok := binaries[binary]
+	if !ok {
+		return fmt.Errorf("Error: fake binary must be named zbox or zwallet, not %q", binary)
+	}
+	if len(argv) < 2 || strings.HasPrefix(argv[1], "-") {
+		return errors.New("Error: missing subcommand")
+	}
+	switch argv[1] {
+	case "version":
+		fmt.Println("Version info:")
+		fmt.Printf("\t%s...: %s\n", binary, fakeVersion)
+		return nil
+	case "help":
+		printHelp(binary, commands)
+		return nil
+	}
+
+	inv := &invocation{subcommand: argv[1], flags: make(map[string]string)}
+	inv.parse(argv[2:])
+
+	cmd, ok := commands[inv.subcommand]
+	if !ok {
+		return fmt.Errorf("Error: unknown command %q for %q", inv.subcommand, binary)
+	}
+	if _, ok := inv.flags["help"]; ok {
+		printCommandHelp(binary, inv.subcommand, cmd)
+		return nil
+	}
+
+	inv.configDir = inv.flags["configDir"]
+	if inv.configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		inv.configDir = filepath.Join(home, ".zcn")
+	}
+
+	return withChain(inv.configDir, func(c *chain) error {
+		inv.chain = c
+		if err := c.injectedFailure(inv.subcommand, inv.flags["wallet"]); err != nil {
+			return err
+		}
+		return cmd.run(inv)
+	})
+}
+
+// parse accepts --name value and --name=value flags, positional arguments are not used by any subcommand
+func (inv *invocation) parse(args []string) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "--") {
+			continue
+		}
+
+		name := strings.TrimPrefix(arg, "--")
+		if j := strings.Index(name, "="); j >= 0 {
+			inv.flags[name[:j]] = name[j+1:]
+			continue
+		}
+		if boolFlags[name] || i+1 == len(args) || strings.HasPrefix(args[i+1], "--") {
+			inv.flags[name] = "true"
+			continue
+		}
+		inv.flags[name] = args[i+1]
+		i++
+	}
+}
+
+func (inv *invocation) flag(name string) (string, bool) {
+	value, ok := inv.flags[name]
+	return value, ok
+}
+
+// requiredFlag fails the same way as the real binaries when a mandatory flag is missing
+func (inv *invocation) requiredFlag(name string) (string, error) {
+	value, ok := inv.flags[name]
+	if !ok || value == "" {
+		return "", fmt.Errorf("Error: %s flag is missing", name)
+	}
+	return value, nil
+}
+
+func (inv *invocation) boolFlag(name string) bool {
+	value, ok := inv.flags[name]
+	if !ok {
+		return false
+	}
+	b, err := strconv.ParseBool(value)
+	return err == nil && b
+}
+
+// tokens parses a flag given in ZCN, returning SAS
+func (inv *invocation) tokens(name string) (int64, error) {
+	value, err := inv.requiredFlag(name)
+	if err != nil {
+		return 0, err
+	}
+	zcn, err := strconv.ParseFloat(value, 64)
+	if err != nil || zcn < 0 {
+		return 0, fmt.Errorf("Error: invalid %s %q", name, value)
+	}
+	return zcnToSAS(zcn), nil
+}
+
+// wallet returns the wallet of the --wallet file, creating it like the real binaries do when it does not exist
+func (inv *invocation) wallet() (*walletFile, error) {
+	name := inv.flags["wallet"]
+	if name == "" {
+		name = "wallet.json"
+	}
+	w, err := loadOrCreateWallet(filepath.Join(inv.configDir, name))
+	if err != nil {
+		return nil, err
+	}
+	inv.chain.account(w.ClientID)
+	return w, nil
+}
+
+// printHelp lists the subcommands in the layout of cobra help output
+func printHelp(binary string, commands map[string]command) {
+	fmt.Printf("Fake %s for offline tests of the system test suites\n\n", binary)
+	fmt.Println("Usage:")
+	fmt.Printf("  %s [command]\n\n", binary)
+	fmt.Println("Available Commands:")
+	for _, name := range sortedKeys(commands) {
+		fmt.Printf("  %-15s fake %s\n", name, name)
+	}
+}
+
+func printCommandHelp(binary, subcommand string, cmd command) {
+	fmt.Println("Usage:")
+	fmt.Printf("  %s %s [flags]\n\n", binary, subcommand)
+	fmt.Println("Flags:")
+	fmt.Println("  -h, --help   help for " + subcommand)
+	for _, flag := range cmd.flags {
+		fmt.Printf("      --%s\n", flag)
+	}
+	fmt.Println()
+	fmt.Println("Global Flags:")
+	for _, flag := range globalFlags {
+		fmt.Printf("      --%s\n", flag)
+	}
+}
+
+func printJSON(value interface{}) error {
+	content, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(content))
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
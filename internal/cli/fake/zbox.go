@@ -0,0 +1,494 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	climodel "github.com/0chain/system_test/internal/cli/model"
+)
+
+const (
+	fakeBlobbers = 6
+
+	defaultAllocationSize   = 2147483648
+	minAllocationSize       = 1024
+	defaultAllocationExpiry = 720 * time.Hour
+)
+
+var zboxCommands = map[string]command{
+	"register":      {register, nil},
+	"getwallet":     {getWallet, []string{"json"}},
+	"ls-blobbers":   {listBlobbers, []string{"all", "json"}},
+	"newallocation": {newAllocation, []string{"allocationFileName", "data", "expire", "lock", "parity", "size"}},
+	"getallocation": {getAllocation, []string{"allocation", "json"}},
+	"alloc-cancel":  {cancelAllocation, []string{"allocation"}},
+	"rp-lock":       {readPoolLock, []string{"tokens"}},
+	"rp-info":       {readPoolInfo, []string{"json"}},
+	"upload":        {upload, []string{"allocation", "localpath", "remotepath"}},
+	"download":      {download, []string{"allocation", "localpath", "remotepath"}},
+	"list":          {list, []string{"allocation", "json", "remotepath"}},
+	"list-all":      {listAll, []string{"allocation"}},
+	"stats":         {stats, []string{"allocation", "json", "remotepath"}},
+}
+
+func getWallet(inv *invocation) error {
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+	return printJSON(climodel.Wallet{
+		ClientID:            w.ClientID,
+		ClientPublicKey:     w.ClientKey,
+		EncryptionPublicKey: fixedHash("encryption-" + w.ClientID),
+	})
+}
+
+func blobbers() []climodel.BlobberDetails {
+	details := make([]climodel.BlobberDetails, 0, fakeBlobbers)
+	for i := 1; i <= fakeBlobbers; i++ {
+		details = append(details, climodel.BlobberDetails{
+			ID:              fixedHash("blobber-" + strconv.Itoa(i)),
+			BaseURL:         fmt.Sprintf("http://localhost:%d", 5050+i),
+			Terms:           climodel.Terms{Read_price: sasPerZCN / 100, Write_price: sasPerZCN / 10, Max_offer_duration: 744 * time.Hour},
+			Capacity:        1 << 40,
+			LastHealthCheck: time.Now().Unix(),
+			IsAvailable:     true,
+		})
+	}
+	return details
+}
+
+func listBlobbers(inv *invocation) error {
+	if inv.boolFlag("json") {
+		return printJSON(blobbers())
+	}
+	for _, b := range blobbers() {
+		fmt.Println("- id:", b.ID)
+		fmt.Println("  url:", b.BaseURL)
+	}
+	return nil
+}
+
+func newAllocation(inv *invocation) error {
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+	lock, err := inv.tokens("lock")
+	if err != nil {
+		return err
+	}
+
+	size, err := intFlag(inv, "size", defaultAllocationSize)
+	if err != nil {
+		return err
+	}
+	data, err := intFlag(inv, "data", 2)
+	if err != nil {
+		return err
+	}
+	parity, err := intFlag(inv, "parity", 2)
+	if err != nil {
+		return err
+	}
+	expiry := defaultAllocationExpiry
+	if value, ok := inv.flag("expire"); ok {
+		if expiry, err = time.ParseDuration(value); err != nil {
+			return fmt.Errorf("Error: invalid expire %q", value)
+		}
+	}
+
+	switch {
+	case data+parity > fakeBlobbers:
+		return errors.New("Error creating allocation: failed_get_allocation_blobbers: failed to get blobbers for allocation: not enough blobbers to honor the allocation")
+	case size < minAllocationSize:
+		return errors.New("Error creating allocation: allocation_creation_failed: invalid request: insufficient allocation size")
+	case expiry <= 0:
+		return errors.New("Error creating allocation: allocation_creation_failed: invalid request: insufficient allocation duration")
+	}
+
+	owner := inv.chain.account(w.ClientID)
+	if owner.Balance < lock {
+		return errors.New("Error creating allocation: allocation_creation_failed: not enough tokens to honor the allocation")
+	}
+	owner.Balance -= lock
+	owner.Nonce++
+
+	alloc := &allocation{
+		ID:         inv.chain.transaction("newallocation"),
+		Owner:      w.ClientID,
+		OwnerKey:   w.ClientKey,
+		Size:       int64(size),
+		Data:       data,
+		Parity:     parity,
+		Expiration: time.Now().Add(expiry).Unix(),
+		WritePool:  lock,
+		Files:      make(map[string]*file),
+	}
+	inv.chain.Allocations[alloc.ID] = alloc
+
+	// like zbox, the allocation ID is saved in the config dir
+	name, ok := inv.flag("allocationFileName")
+	if !ok {
+		name = "allocation.txt"
+	}
+	if err := os.WriteFile(filepath.Join(inv.configDir, name), []byte(alloc.ID), 0644); err != nil { //nolint:gosec
+		return err
+	}
+
+	fmt.Println("Allocation created: " + alloc.ID)
+	return nil
+}
+
+func intFlag(inv *invocation, name string, defaultValue int) (int, error) {
+	value, ok := inv.flag(name)
+	if !ok {
+		return defaultValue, nil
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("Error: invalid %s %q", name, value)
+	}
+	return i, nil
+}
+
+// allocation returns the allocation of the --allocation flag, failing with errPrefix if it does not exist
+func (inv *invocation) allocation(errPrefix string) (*allocation, error) {
+	id, err := inv.requiredFlag("allocation")
+	if err != nil {
+		return nil, err
+	}
+	alloc, ok := inv.chain.Allocations[id]
+	if !ok {
+		return nil, errors.New(errPrefix + "value not present")
+	}
+	return alloc, nil
+}
+
+func (a *allocation) model() climodel.Allocation {
+	allocationBlobbers := make([]climodel.Blobber, 0, a.Data+a.Parity)
+	for _, b := range blobbers()[:a.Data+a.Parity] {
+		allocationBlobbers = append(allocationBlobbers, climodel.Blobber{ID: b.ID, Baseurl: b.BaseURL})
+	}
+	return climodel.Allocation{
+		ID:             a.ID,
+		Tx:             a.ID,
+		ExpirationDate: a.Expiration,
+		DataShards:     a.Data,
+		ParityShards:   a.Parity,
+		Size:           a.Size,
+		Owner:          a.Owner,
+		OwnerPublicKey: a.OwnerKey,
+		Payer:          a.Owner,
+		Blobbers:       allocationBlobbers,
+		WritePool:      a.WritePool,
+		Canceled:       a.Canceled,
+	}
+}
+
+func getAllocation(inv *invocation) error {
+	alloc, err := inv.allocation("Error fetching the allocation allocation_fetch_error: ")
+	if err != nil {
+		return err
+	}
+	return printJSON(alloc.model())
+}
+
+func cancelAllocation(inv *invocation) error {
+	const errPrefix = "Error creating allocation:alloc_cancel_failed: "
+
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+	alloc, err := inv.allocation(errPrefix)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case alloc.Owner != w.ClientID:
+		return errors.New(errPrefix + "only owner can cancel an allocation")
+	case alloc.Expiration <= time.Now().Unix():
+		return errors.New(errPrefix + "trying to cancel expired allocation")
+	case alloc.Canceled:
+		return errors.New(errPrefix + "allocation already canceled")
+	}
+
+	inv.chain.account(w.ClientID).Balance += alloc.WritePool
+	alloc.WritePool = 0
+	alloc.Canceled = true
+
+	fmt.Println("Allocation canceled with txId : " + inv.chain.transaction("alloc-cancel"))
+	return nil
+}
+
+func readPoolLock(inv *invocation) error {
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+	tokens, err := inv.tokens("tokens")
+	if err != nil {
+		return err
+	}
+
+	a := inv.chain.account(w.ClientID)
+	if a.Balance < tokens {
+		return errors.New("Failed to lock tokens in read pool: read_pool_lock_failed: lock amount is greater than balance")
+	}
+	a.Balance -= tokens
+	a.ReadPool += tokens
+	a.Nonce++
+
+	fmt.Println("locked")
+	return nil
+}
+
+func readPoolInfo(inv *invocation) error {
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+
+	balance := inv.chain.account(w.ClientID).ReadPool
+	info := climodel.ReadPoolInfo{Balance: balance, Zcn: sasToZCN(balance), Usd: sasToZCN(balance) * zcnPrice}
+	if inv.boolFlag("json") {
+		return printJSON(info)
+	}
+	fmt.Printf("Read pool Balance: %s (%.2f USD)\n", formatBalance(balance), info.Usd)
+	return nil
+}
+
+// writableAllocation returns the allocation of the --allocation flag if the wallet owns it and it is still active
+func (inv *invocation) writableAllocation(errPrefix string) (*allocation, error) {
+	w, err := inv.wallet()
+	if err != nil {
+		return nil, err
+	}
+	alloc, err := inv.allocation(errPrefix)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case alloc.Owner != w.ClientID:
+		return nil, errors.New(errPrefix + "Operation needs to be performed by the owner or the payer of the allocation")
+	case alloc.Canceled || alloc.Expiration <= time.Now().Unix():
+		return nil, errors.New(errPrefix + "allocation is finalized or canceled")
+	}
+	return alloc, nil
+}
+
+func upload(inv *invocation) error {
+	const errPrefix = "Upload failed. "
+
+	alloc, err := inv.writableAllocation(errPrefix)
+	if err != nil {
+		return err
+	}
+	localPath, err := inv.requiredFlag("localpath")
+	if err != nil {
+		return err
+	}
+	remotePath, err := inv.requiredFlag("remotepath")
+	if err != nil {
+		return err
+	}
+	if strings.HasSuffix(remotePath, "/") {
+		remotePath += filepath.Base(localPath)
+	}
+	remotePath = path.Clean("/" + remotePath)
+
+	content, err := os.ReadFile(localPath)
+	if err != nil {
+		return errors.New(errPrefix + err.Error())
+	}
+	if _, ok := alloc.Files[remotePath]; ok {
+		return errors.New(errPrefix + "Upload failed: file already exists")
+	}
+	if alloc.used()+int64(len(content)) > alloc.Size {
+		return errors.New(errPrefix + "max_allocation_size_exceeded: Max size reached for the allocation with this blobber")
+	}
+
+	alloc.Files[remotePath] = &file{Content: content, CreatedAt: time.Now().Unix()}
+
+	printProgress(len(content))
+	fmt.Printf("Status completed callback. Type = application/octet-stream. Name = %s\n", path.Base(remotePath))
+	return nil
+}
+
+func (a *allocation) used() int64 {
+	var used int64
+	for _, f := range a.Files {
+		used += int64(len(f.Content))
+	}
+	return used
+}
+
+func printProgress(size int) {
+	fmt.Printf(" %d B / %d B [==========] 100.00%% 0s\n", size, size)
+}
+
+func download(inv *invocation) error {
+	const errPrefix = "Error in file operation: "
+
+	alloc, err := inv.allocation(errPrefix)
+	if err != nil {
+		return err
+	}
+	remotePath, err := inv.requiredFlag("remotepath")
+	if err != nil {
+		return err
+	}
+	localPath, err := inv.requiredFlag("localpath")
+	if err != nil {
+		return err
+	}
+	remotePath = path.Clean("/" + remotePath)
+
+	f, ok := alloc.Files[remotePath]
+	if !ok {
+		return errors.New(errPrefix + "consensus_not_met: file not found")
+	}
+
+	if stat, err := os.Stat(localPath); err == nil && stat.IsDir() {
+		localPath = filepath.Join(localPath, path.Base(remotePath))
+	}
+	if _, err := os.Stat(localPath); err == nil {
+		return errors.New(errPrefix + "Local file already exists")
+	}
+	if err := os.WriteFile(localPath, f.Content, 0644); err != nil { //nolint:gosec
+		return errors.New(errPrefix + err.Error())
+	}
+
+	printProgress(len(f.Content))
+	fmt.Printf("Status completed callback. Type = application/octet-stream. Name = %s\n", path.Base(remotePath))
+	return nil
+}
+
+// entries returns the files of the allocation and the directories containing them, by path
+func (a *allocation) entries() map[string]climodel.ListFileResult {
+	entries := make(map[string]climodel.ListFileResult)
+	for remotePath, f := range a.Files {
+		entries[remotePath] = climodel.ListFileResult{
+			Name:            path.Base(remotePath),
+			Path:            remotePath,
+			Type:            "f",
+			Size:            int64(len(f.Content)),
+			Hash:            hashOf(f.Content),
+			Mimetype:        "application/octet-stream",
+			NumBlocks:       blocks(len(f.Content)),
+			LookupHash:      fixedHash(a.ID + ":" + remotePath),
+			ActualSize:      int64(len(f.Content)),
+			ActualNumBlocks: blocks(len(f.Content)),
+			CreatedAt:       climodel.Timestamp(f.CreatedAt),
+			UpdatedAt:       climodel.Timestamp(f.CreatedAt),
+		}
+		for dir := path.Dir(remotePath); dir != "/"; dir = path.Dir(dir) {
+			if _, ok := entries[dir]; !ok {
+				entries[dir] = climodel.ListFileResult{Name: path.Base(dir), Path: dir, Type: "d", LookupHash: fixedHash(a.ID + ":" + dir)}
+			}
+		}
+	}
+	return entries
+}
+
+func hashOf(content []byte) string {
+	return fixedHash(string(content))
+}
+
+// blocks returns the number of 64KB blocks a file is split into
+func blocks(size int) int {
+	return (size + 65535) / 65536
+}
+
+func list(inv *invocation) error {
+	alloc, err := inv.allocation("Error in file operation: ")
+	if err != nil {
+		return err
+	}
+	remotePath, err := inv.requiredFlag("remotepath")
+	if err != nil {
+		return err
+	}
+	remotePath = path.Clean("/" + remotePath)
+
+	entries := alloc.entries()
+	files := make([]climodel.ListFileResult, 0)
+	for _, p := range sortedKeys(entries) {
+		if path.Dir(p) == remotePath && p != "/" {
+			files = append(files, entries[p])
+		}
+	}
+
+	if inv.boolFlag("json") {
+		return printJSON(files)
+	}
+	fmt.Println("  TYPE | NAME | PATH | SIZE | NUM BLOCKS | LOOKUP HASH | IS ENCRYPTED")
+	for _, f := range files {
+		fmt.Printf("  %s | %s | %s | %d | %d | %s | NO\n", f.Type, f.Name, f.Path, f.Size, f.NumBlocks, f.LookupHash)
+	}
+	return nil
+}
+
+func listAll(inv *invocation) error {
+	alloc, err := inv.allocation("Error in file operation: ")
+	if err != nil {
+		return err
+	}
+
+	entries := alloc.entries()
+	files := make([]climodel.AllocationFile, 0, len(entries))
+	for _, p := range sortedKeys(entries) {
+		entry := entries[p]
+		files = append(files, climodel.AllocationFile{Name: entry.Name, Path: entry.Path, Type: entry.Type, Size: int(entry.Size), Hash: entry.Hash})
+	}
+	return printJSON(files)
+}
+
+func stats(inv *invocation) error {
+	alloc, err := inv.allocation("Error in file operation: ")
+	if err != nil {
+		return err
+	}
+	remotePath, err := inv.requiredFlag("remotepath")
+	if err != nil {
+		return err
+	}
+	remotePath = path.Clean("/" + remotePath)
+
+	entry, ok := alloc.entries()[remotePath]
+	if !ok {
+		return errors.New("Error in file operation: consensus_not_met: file not found")
+	}
+
+	fileStats := make(map[string]climodel.FileStats)
+	for _, b := range alloc.model().Blobbers {
+		fileStats[b.ID] = climodel.FileStats{
+			Name:            entry.Name,
+			Size:            entry.Size,
+			PathHash:        entry.LookupHash,
+			Path:            entry.Path,
+			NumOfBlocks:     int64(entry.NumBlocks),
+			NumOfUpdates:    1,
+			BlobberID:       b.ID,
+			BlobberURL:      b.Baseurl,
+			BlockchainAware: true,
+			CreatedAt:       time.Unix(int64(entry.CreatedAt), 0),
+		}
+	}
+	if inv.boolFlag("json") {
+		return printJSON(fileStats)
+	}
+	for _, id := range sortedKeys(fileStats) {
+		s := fileStats[id]
+		fmt.Printf("  %s | %s | %s | %d | %d\n", s.BlobberID, s.Name, s.Path, s.Size, s.NumOfBlocks)
+	}
+	return nil
+}
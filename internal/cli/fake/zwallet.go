@@ -0,0 +1,178 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	climodel "github.com/0chain/system_test/internal/cli/model"
+)
+
+const (
+	fakeMiners   = 3
+	fakeSharders = 2
+)
+
+var zwalletCommands = map[string]command{
+	"register":    {register, nil},
+	"faucet":      {faucet, []string{"methodName", "tokens", "input"}},
+	"getbalance":  {getBalance, nil},
+	"getnonce":    {getNonce, nil},
+	"send":        {send, []string{"to_client_id", "tokens", "desc", "fee"}},
+	"verify":      {verify, []string{"hash"}},
+	"ls-miners":   {listMiners, []string{"active", "json"}},
+	"ls-sharders": {listSharders, []string{"active", "json"}},
+}
+
+// register prints what zbox and zwallet print when creating a wallet, the wallet itself is created on first use
+func register(inv *invocation) error {
+	if _, err := inv.wallet(); err != nil {
+		return err
+	}
+	fmt.Println("ZCN wallet created")
+	fmt.Println("Creating related read pool for storage smart-contract...")
+	fmt.Println("Read pool created successfully")
+	fmt.Println("Wallet registered")
+	return nil
+}
+
+func faucet(inv *invocation) error {
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+	tokens, err := inv.tokens("tokens")
+	if err != nil {
+		return err
+	}
+
+	inv.chain.account(w.ClientID).Balance += tokens
+	fmt.Println("Execute faucet smart contract success with txn :  " + inv.chain.transaction("faucet"))
+	return nil
+}
+
+func getBalance(inv *invocation) error {
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+
+	balance := inv.chain.account(w.ClientID).Balance
+	fmt.Printf("Balance: %s (%.2f USD)\n", formatBalance(balance), sasToZCN(balance)*zcnPrice)
+	return nil
+}
+
+func getNonce(inv *invocation) error {
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+	fmt.Println("Nonce:", inv.chain.account(w.ClientID).Nonce)
+	return nil
+}
+
+func send(inv *invocation) error {
+	w, err := inv.wallet()
+	if err != nil {
+		return err
+	}
+	to, err := inv.requiredFlag("to_client_id")
+	if err != nil {
+		return err
+	}
+	tokens, err := inv.tokens("tokens")
+	if err != nil {
+		return err
+	}
+	var fee int64
+	if _, ok := inv.flag("fee"); ok {
+		if fee, err = inv.tokens("fee"); err != nil {
+			return err
+		}
+	}
+
+	from := inv.chain.account(w.ClientID)
+	if from.Balance < tokens+fee {
+		return errors.New(`Send tokens failed. submit transaction failed. {"code":"invalid_request","error":"invalid_request: Invalid request (insufficient balance to pay fee)"}`)
+	}
+	from.Balance -= tokens + fee
+	from.Nonce++
+	inv.chain.account(to).Balance += tokens
+
+	fmt.Println("Send tokens success:  " + inv.chain.transaction("send"))
+	return nil
+}
+
+func verify(inv *invocation) error {
+	hash, err := inv.requiredFlag("hash")
+	if err != nil {
+		return err
+	}
+	kind, ok := inv.chain.Transactions[hash]
+	if !ok {
+		return errors.New("Error verifying the transaction: transaction not found")
+	}
+
+	fmt.Println("Transaction verification success")
+	fmt.Println("TransactionStatus: 1")
+	return printJSON(map[string]string{"hash": hash, "transaction_output": kind})
+}
+
+func listMiners(inv *invocation) error {
+	var miners climodel.NodeList
+	for i := 1; i <= fakeMiners; i++ {
+		id := fixedHash("miner-" + strconv.Itoa(i))
+		node := climodel.Node{SimpleNode: climodel.SimpleNode{
+			ID:         id,
+			N2NHost:    "localhost",
+			Host:       "localhost",
+			Port:       7070 + i,
+			PublicKey:  id + id,
+			ShortName:  fmt.Sprintf("miner-%d", i),
+			BuildTag:   "fake",
+			TotalStake: 10 * sasPerZCN,
+		}}
+		miners.Nodes = append(miners.Nodes, node)
+	}
+	return printListing(inv, miners)
+}
+
+func listSharders(inv *invocation) error {
+	sharders := make(map[string]climodel.Sharder)
+	for i := 1; i <= fakeSharders; i++ {
+		id := fixedHash("sharder-" + strconv.Itoa(i))
+		sharder := climodel.Sharder{
+			ID:           id,
+			Version:      "1.0",
+			CreationDate: time.Now().Unix(),
+			PublicKey:    id + id,
+			N2NHost:      "localhost",
+			Host:         "localhost",
+			Port:         7170 + i,
+			Type:         2,
+			Description:  fmt.Sprintf("sharder-%d", i),
+		}
+		sharder.Info.BuildTag = "fake"
+		sharders[id] = sharder
+	}
+	return printListing(inv, sharders)
+}
+
+// printListing prints JSON with --json, and the IDs of the nodes otherwise
+func printListing(inv *invocation, value interface{}) error {
+	if inv.boolFlag("json") {
+		return printJSON(value)
+	}
+	switch nodes := value.(type) {
+	case climodel.NodeList:
+		for _, node := range nodes.Nodes {
+			fmt.Println("- ID:", node.ID)
+		}
+	case map[string]climodel.Sharder:
+		for _, id := range sortedKeys(nodes) {
+			fmt.Println("- ID:", id)
+		}
+	}
+	return nil
+}
@@ -1,120 +1,195 @@
 package cliutils
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"strconv"
+	"sync"
 
 	"github.com/0chain/system_test/internal/api/util/test"
-	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func ApiGetRetries[T any](t *test.SystemTest, url string, params map[string]string, retries int) *T {
-	var err error
-	var res *T
-	for try := 1; try <= retries; try++ {
-		res, err = ApiGetError[T](url, params)
-		if err != nil {
-			t.Logf("retry %d, %v", try, err)
-		} else {
-			break
-		}
-	}
-	assert.NoError(t, err, "%s failed after %d retries", url, retries)
-
-	return res
-}
+const (
+	// listWorkers is the number of round chunks ApiGetList fetches concurrently
+	listWorkers = 4
+	// listChunkRounds is the number of rounds fetched by one worker at a time
+	listChunkRounds = 100
+)
 
-func ApiGetError[T any](url string, params map[string]string) (*T, error) {
-	url = addParms(url, params)
+var (
+	listLimitsMutex sync.Mutex
+	// listLimits are the page sizes of endpoints, keyed by the last element of their path
+	listLimits = make(map[string]listLimit)
+)
 
-	res, err := http.Get(url) //nolint:gosec
-	if err != nil {
-		return nil, fmt.Errorf("with request %s, %v", url, err)
-	}
+type listLimit struct {
+	limit int64
+	// confirmed is set once the endpoint returned a full page, so it is known not to cap the limit
+	confirmed bool
+}
 
-	defer res.Body.Close()
-	if res.StatusCode < 200 && res.StatusCode >= 300 {
-		return nil, fmt.Errorf("failed API request %s, status code: %d: %v", url, res.StatusCode, err)
-	}
-	if res.Body == nil {
-		return nil, fmt.Errorf("request %s, API response must not be nil", url)
-	}
-	resBody, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("response %s, reading response body: %v", url, err)
-	}
+// SetListLimit configures the page size requested from an endpoint, e.g. "get_blocks", instead of MaxQueryLimit.
+// A page size the server caps or rejects is lowered to what the server returns.
+func SetListLimit(endpoint string, limit int64) {
+	listLimitsMutex.Lock()
+	defer listLimitsMutex.Unlock()
+	listLimits[endpoint] = listLimit{limit: limit}
+}
 
-	var result = new(T)
-	err = json.Unmarshal(resBody, &result)
-	if err != nil {
-		return nil, fmt.Errorf("deserializing JSON string `%s`: %v", string(resBody), err)
+func getListLimit(endpoint string) listLimit {
+	listLimitsMutex.Lock()
+	defer listLimitsMutex.Unlock()
+	if l, ok := listLimits[endpoint]; ok {
+		return l
 	}
-	return result, nil
+	return listLimit{limit: MaxQueryLimit}
 }
 
-func ApiGet[T any](t *test.SystemTest, url string, params map[string]string) *T {
-	url = addParms(url, params)
+func setListLimit(endpoint string, l listLimit) {
+	listLimitsMutex.Lock()
+	defer listLimitsMutex.Unlock()
+	listLimits[endpoint] = l
+}
 
-	res, err := http.Get(url) //nolint:gosec
+// ApiGetList fetches all items of rounds from to to, excluding to, from a paginated endpoint. The rounds are split
+// into chunks paged through concurrently, and the items are returned in the order of their chunks.
+func ApiGetList[T any](t *test.SystemTest, url string, params map[string]string, from, to int64) []T {
+	items, err := apiGetList[T](t.Context(), url, params, from, to)
+	require.NoError(t, err)
+	return items
+}
 
-	require.NoError(t, err, "with request", url)
-	defer res.Body.Close()
-	require.True(t, res.StatusCode >= 200 && res.StatusCode < 300,
-		"failed API request %s, status code: %d", url, res.StatusCode)
-	require.NotNil(t, res.Body, "API response must not be nil")
+// apiGetList is ApiGetList returning the error of the first chunk which could not be retrieved
+func apiGetList[T any](ctx context.Context, url string, params map[string]string, from, to int64) ([]T, error) {
+	var chunks [][2]int64
+	for start := from; start < to; start += listChunkRounds {
+		end := start + listChunkRounds
+		if end > to {
+			end = to
+		}
+		chunks = append(chunks, [2]int64{start, end})
+	}
 
-	resBody, err := io.ReadAll(res.Body)
-	require.NoError(t, err, "reading response body: %v", err)
+	results := make([][]T, len(chunks))
+	errs := make([]error, len(chunks))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < listWorkers && w < len(chunks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				results[i], errs[i] = getChunk[T](ctx, url, params, chunks[i][0], chunks[i][1])
+			}
+		}()
+	}
+	for i := range chunks {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
 
-	var result = new(T)
-	err = json.Unmarshal(resBody, &result)
-	require.NoError(t, err, "deserializing JSON string `%s`: %v", string(resBody), err)
-	return result
+	var out []T
+	for i := range chunks {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("retrieving rounds %d to %d of %s: %w", chunks[i][0], chunks[i][1], url, errs[i])
+		}
+		out = append(out, results[i]...)
+	}
+	return out, nil
 }
 
-func ApiGetList[T any](t *test.SystemTest, url string, params map[string]string, from, to int64) []T {
+// getChunk pages through the items of rounds from to to
+func getChunk[T any](ctx context.Context, url string, params map[string]string, from, to int64) ([]T, error) {
+	endpoint := path.Base(url)
 	var out []T
 	var offset int64
 	for {
-		var temp []T
-		raw := getNext(t, url, from, to, MaxQueryLimit, offset, params)
-
-		err := json.Unmarshal(raw, &temp)
-		assert.NoError(t, err, "deserializing JSON string `%s`: %v", string(raw), err)
-		out = append(out, temp...)
-		if len(temp) < MaxQueryLimit {
-			return out
+		limit := getListLimit(endpoint)
+		var page []T
+		status, err := getNext(ctx, url, from, to, limit.limit, offset, params, &page)
+		if err != nil {
+			if status >= 400 && status < 500 && limit.limit > MaxQueryLimit {
+				// the server rejects the limit, fall back to the default page size
+				setListLimit(endpoint, listLimit{limit: MaxQueryLimit})
+				continue
+			}
+			return nil, err
+		}
+		out = append(out, page...)
+		offset += int64(len(page))
+
+		switch {
+		case int64(len(page)) >= limit.limit:
+			if !limit.confirmed {
+				setListLimit(endpoint, listLimit{limit: limit.limit, confirmed: true})
+			}
+			continue
+		case len(page) == 0 || limit.confirmed:
+			return out, nil
 		}
 
-		offset += int64(len(temp))
+		// a short page is either the last one, or the server caps the limit; probe the next page to tell
+		var probe []T
+		if _, err := getNext(ctx, url, from, to, limit.limit, offset, params, &probe); err != nil {
+			return nil, err
+		}
+		if len(probe) == 0 {
+			return out, nil
+		}
+		setListLimit(endpoint, listLimit{limit: int64(len(page)), confirmed: true})
+		out = append(out, probe...)
+		offset += int64(len(probe))
 	}
 }
 
-func getNext(t *test.SystemTest, url string, from, to, limit, offset int64, params map[string]string) []byte {
-	params["start"] = strconv.FormatInt(from, 10)
-	params["end"] = strconv.FormatInt(to, 10)
+// getNext fetches a page into result, returning the status code of the response
+func getNext(ctx context.Context, url string, from, to, limit, offset int64, params map[string]string, result interface{}) (int, error) {
+	query := make(map[string]string, len(params)+4)
+	for name, value := range params {
+		query[name] = value
+	}
+	query["start"] = strconv.FormatInt(from, 10)
+	query["end"] = strconv.FormatInt(to, 10)
 	if limit > 0 {
-		params["limit"] = strconv.FormatInt(limit, 10)
+		query["limit"] = strconv.FormatInt(limit, 10)
 	}
 	if offset > 0 {
-		params["offset"] = strconv.FormatInt(offset, 10)
+		query["offset"] = strconv.FormatInt(offset, 10)
 	}
-	url = addParms(url, params)
-	res, err := http.Get(url) //nolint:gosec
+	url = addParms(url, query)
 
-	require.NoError(t, err, "retrieving blocks %d to %d", from, to)
+	res, err := httpGet(ctx, url)
+	if err != nil {
+		return 0, fmt.Errorf("with request %s, %v", url, err)
+	}
 	defer res.Body.Close()
-	require.True(t, res.StatusCode >= 200 && res.StatusCode < 300,
-		"failed API request to get blocks %d to %d, status code: %d", from, to, res.StatusCode)
-	require.NotNil(t, res.Body, "balance API response must not be nil")
 
 	resBody, err := io.ReadAll(res.Body)
-	require.NoError(t, err, "reading response body: %v", err)
-	return resBody
+	if err != nil {
+		return res.StatusCode, fmt.Errorf("response %s, reading response body: %v", url, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("failed API request %s, status code: %d: %s", url, res.StatusCode, string(resBody))
+	}
+	if err := json.Unmarshal(resBody, result); err != nil {
+		return res.StatusCode, fmt.Errorf("deserializing JSON string `%s`: %v", string(resBody), err)
+	}
+	return res.StatusCode, nil
+}
+
+// httpGet sends a GET request with the context, which carries the cassette of the test case if it is the test context
+func httpGet(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return httpClient.Do(req)
 }
 
 func addParms(url string, params map[string]string) string {
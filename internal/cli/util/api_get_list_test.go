@@ -0,0 +1,79 @@
+package cliutils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+type roundItem struct {
+	Round int64 `json:"round"`
+	Index int   `json:"index"`
+}
+
+// listServer serves two items per round, paging them by limit and offset. Pages are capped at maxPage items,
+// and limits above maxLimit are rejected, when those are set.
+func listServer(maxPage, maxLimit int64) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		start, _ := strconv.ParseInt(query.Get("start"), 10, 64)
+		end, _ := strconv.ParseInt(query.Get("end"), 10, 64)
+		limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+		offset, _ := strconv.ParseInt(query.Get("offset"), 10, 64)
+		if maxLimit > 0 && limit > maxLimit {
+			http.Error(w, "limit too large", http.StatusBadRequest)
+			return
+		}
+		if maxPage > 0 && limit > maxPage {
+			limit = maxPage
+		}
+
+		var items []roundItem
+		for round := start; round < end; round++ {
+			items = append(items, roundItem{round, 0}, roundItem{round, 1})
+		}
+		page := []roundItem{}
+		for i := offset; i < int64(len(items)) && i < offset+limit; i++ {
+			page = append(page, items[i])
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	}))
+}
+
+func TestApiGetListPaging(testSetup *testing.T) {
+	tests := map[string]struct {
+		endpoint  string
+		limit     int64
+		maxPage   int64
+		maxLimit  int64
+		wantLimit int64
+	}{
+		"pages of the requested limit": {endpoint: "get_full_pages", limit: 30, wantLimit: 30},
+		"pages capped by the server":   {endpoint: "get_capped_pages", limit: 30, maxPage: 7, wantLimit: 7},
+		"limit rejected by the server": {endpoint: "get_rejected_limit", limit: 50, maxLimit: MaxQueryLimit, wantLimit: MaxQueryLimit},
+	}
+
+	var want []roundItem
+	for round := int64(5); round < 255; round++ {
+		want = append(want, roundItem{round, 0}, roundItem{round, 1})
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		testSetup.Run(name, func(testSetup *testing.T) {
+			t := test.NewSystemTest(testSetup)
+			server := listServer(tt.maxPage, tt.maxLimit)
+			defer server.Close()
+
+			SetListLimit(tt.endpoint, tt.limit)
+			got := ApiGetList[roundItem](t, server.URL+"/v1/"+tt.endpoint, nil, 5, 255)
+			require.Equal(t, want, got)
+			require.Equal(t, tt.wantLimit, getListLimit(tt.endpoint).limit)
+		})
+	}
+}
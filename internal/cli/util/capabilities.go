@@ -0,0 +1,115 @@
+package cliutils
+
+import (
+	"context"
+	"errors"
+	"log"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+const detectTimeout = 30 * time.Second
+
+var (
+	anyVersionRegex = regexp.MustCompile(`v?(\d+\.\d+(?:\.\d+)?[^\s]*)`)
+	commandRegex    = regexp.MustCompile(`^\s{2}([a-zA-Z][\w-]*)\s`)
+	flagRegex       = regexp.MustCompile(`^\s+(?:-\w, )?--([a-zA-Z][\w-]*)`)
+)
+
+// DetectCLI queries the version and subcommands of a binary, e.g. "./zbox", run with the config of the suite,
+// and registers them for test.RequireCLI. Flags of a subcommand are queried from its help output once a test case
+// requires them. If detection fails, c.Err is set and test cases requiring the binary run with a warning.
+func DetectCLI(binary, configDir, configFile string) *test.CLICapabilities {
+	name := strings.TrimSuffix(filepath.Base(binary), ".exe")
+	c := &test.CLICapabilities{Binary: name, Commands: make(map[string]bool)}
+	defer test.RegisterCLI(c)
+
+	ctx, cancel := context.WithTimeout(context.Background(), detectTimeout)
+	defer cancel()
+
+	command := func(subcommand string, args ...string) *Command {
+		return NewCommand(binary, subcommand).WithArgs(args...).WithConfigDir(configDir).WithConfig(configFile)
+	}
+
+	result, err := command("version").Execute(ctx)
+	if err != nil {
+		c.Err = err
+		log.Printf("Failed to detect version of [%s] due to error: %v", binary, err)
+		return c
+	}
+	c.Version = parseCLIVersion(name, string(result.Combined))
+
+	result, err = command("help").Execute(ctx)
+	if err != nil {
+		c.Err = err
+		log.Printf("Failed to detect subcommands of [%s] due to error: %v", binary, err)
+		return c
+	}
+	c.Commands = parseHelpSection(string(result.Combined), "Available Commands:", commandRegex)
+	if len(c.Commands) == 0 {
+		c.Err = errors.New("no subcommands listed by help")
+	}
+
+	var flagsMutex sync.Mutex
+	flags := make(map[string]map[string]bool)
+	c.Flags = func(subcommand string) (map[string]bool, error) {
+		flagsMutex.Lock()
+		defer flagsMutex.Unlock()
+		if f, ok := flags[subcommand]; ok {
+			return f, nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), detectTimeout)
+		defer cancel()
+		result, err := command(subcommand, "--help").Execute(ctx)
+		if err != nil {
+			return nil, err
+		}
+		flags[subcommand] = parseHelpSection(string(result.Combined), "Flags:", flagRegex)
+		return flags[subcommand], nil
+	}
+
+	log.Printf("Detected [%s] version [%s] with %d subcommands", binary, c.Version, len(c.Commands))
+	return c
+}
+
+// parseCLIVersion finds the version of the binary in output like "zbox....: v1.8.3", falling back to the first version found
+func parseCLIVersion(name, output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, name) {
+			if match := anyVersionRegex.FindStringSubmatch(line); match != nil {
+				return match[1]
+			}
+		}
+	}
+	if match := anyVersionRegex.FindStringSubmatch(output); match != nil {
+		return match[1]
+	}
+	return ""
+}
+
+// parseHelpSection collects the names matched by the regex in the lines following the header, up to the next header
+// of cobra help output. Sections with the header as a suffix are included too, e.g. "Global Flags:" for "Flags:".
+func parseHelpSection(output, header string, regex *regexp.Regexp) map[string]bool {
+	names := make(map[string]bool)
+	inSection := false
+	for _, line := range strings.Split(output, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasSuffix(trimmed, ":") && !strings.HasPrefix(line, " ") {
+			inSection = strings.HasSuffix(trimmed, header)
+			continue
+		}
+		if !inSection {
+			continue
+		}
+		for _, match := range regex.FindAllStringSubmatch(line, -1) {
+			names[match[1]] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,43 @@
+package cliutils
+
+import (
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/cli/fake/fakecli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapabilities(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	capabilities := DetectCLI("./zbox", "./config", fakecli.ConfigFile)
+	require.NoError(t, capabilities.Err)
+	require.Equal(t, "1.8.0-fake", capabilities.Version)
+	require.True(t, capabilities.Commands["upload"])
+
+	var ran []string
+	requirements := map[string][]string{
+		"supported subcommand and flags": {">=1.8,<2", "upload --localpath --remotepath"},
+		"newer version":                  {">=1.9"},
+		"missing subcommand":             {"", "sync"},
+		"missing flag":                   {"", "upload --encrypt"},
+	}
+	for name, requirement := range requirements {
+		requirement := requirement
+		t.RunSequentially(name, func(t *test.SystemTest) {
+			t.RequireCLI("zbox", requirement[0], requirement[1:]...)
+			ran = append(ran, t.Name())
+		})
+	}
+	require.Equal(t, []string{"TestCapabilities/supported_subcommand_and_flags"}, ran)
+
+	missing := DetectCLI("./missing", "./config", fakecli.ConfigFile)
+	require.Error(t, missing.Err)
+	ranWithoutDetection := false
+	t.RunSequentially("failed detection", func(t *test.SystemTest) {
+		t.RequireCLI("missing", ">=1.8", "sync")
+		ranWithoutDetection = true
+	})
+	require.True(t, ranWithoutDetection, "test cases must run with a warning when the capabilities could not be detected")
+}
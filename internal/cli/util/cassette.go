@@ -0,0 +1,66 @@
+package cliutils
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/cassette"
+)
+
+const requestTimeout = 30 * time.Second
+
+// httpClient sends the REST requests of the helpers, recording them to the cassette of the test case carried by
+// the request context when cassettes are on
+var httpClient = &http.Client{Timeout: requestTimeout, Transport: cassette.Transport(nil)}
+
+// executeWithCassette runs the command, recording it to the cassette of the test case carried by ctx,
+// or serves its recorded result instead when replaying.
+func executeWithCassette(ctx context.Context, commandString string, argv []string) (*CommandResult, error) {
+	c := cassette.ForContext(ctx)
+	if c == nil {
+		return executeCommand(ctx, commandString, argv[0], argv[1:])
+	}
+
+	if cassette.Replaying() {
+		interaction, err := c.Replay(cassette.KindCLI, commandString, "")
+		if err != nil {
+			return &CommandResult{Command: commandString, ExitCode: -1}, err
+		}
+		return replayedResult(commandString, interaction)
+	}
+
+	result, err := executeCommand(ctx, commandString, argv[0], argv[1:])
+	recordResult(c, cassette.KindCLI, result, err)
+	return result, err
+}
+
+func replayedResult(commandString string, interaction cassette.Interaction) (*CommandResult, error) {
+	result := &CommandResult{
+		Command:  commandString,
+		Stdout:   []byte(interaction.Stdout),
+		Stderr:   []byte(interaction.Stderr),
+		Combined: []byte(interaction.Output),
+		ExitCode: interaction.ExitCode,
+	}
+	if interaction.Error != "" {
+		return result, errors.New(interaction.Error)
+	}
+	return result, nil
+}
+
+func recordResult(c *cassette.Cassette, kind string, result *CommandResult, err error) {
+	interaction := cassette.Interaction{
+		Kind:     kind,
+		Request:  result.Command,
+		Stdout:   string(result.Stdout),
+		Stderr:   string(result.Stderr),
+		Output:   string(result.Combined),
+		ExitCode: result.ExitCode,
+	}
+	if err != nil {
+		interaction.Error = err.Error()
+	}
+	c.Record(interaction)
+}
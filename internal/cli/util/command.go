@@ -0,0 +1,183 @@
+package cliutils
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+// Flags are the flags of a command. Flags with a nil value are passed as --name,
+// boolean flags as --name=value and all other flags as --name value.
+type Flags map[string]interface{}
+
+// Args returns the flags as command line arguments, sorted by name.
+func (f Flags) Args() []string {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	args := make([]string, 0, 2*len(f))
+	for _, name := range names {
+		switch value := f[name].(type) {
+		case nil:
+			args = append(args, "--"+name)
+		case bool:
+			args = append(args, "--"+name+"="+strconv.FormatBool(value))
+		default:
+			args = append(args, "--"+name, fmt.Sprintf("%v", value))
+		}
+	}
+	return args
+}
+
+// String returns the flags formatted for a command string run by RunCommand, quoting values containing spaces.
+// Quotes within values are lost when the command string is parsed, use Command to pass them.
+func (f Flags) String() string {
+	args := f.Args()
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t\n") {
+			args[i] = `"` + arg + `"`
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// Command is a zbox or zwallet invocation. It is run with an argument list, so arguments
+// containing spaces or quotes are passed to the binary unchanged.
+type Command struct {
+	Binary     string
+	Subcommand string
+	// Args are passed straight after the subcommand, e.g. positional arguments
+	Args      []string
+	Flags     Flags
+	Wallet    string
+	Config    string
+	ConfigDir string
+	Silent    bool
+}
+
+// NewCommand returns a command running the subcommand of the given binary
+func NewCommand(binary, subcommand string) *Command {
+	return &Command{Binary: binary, Subcommand: subcommand, Flags: Flags{}}
+}
+
+// Zbox returns a zbox command using the wallet and config conventions of the test suites
+func Zbox(subcommand string) *Command {
+	return NewCommand("./zbox", subcommand).WithConfigDir("./config")
+}
+
+// Zwallet returns a zwallet command using the wallet and config conventions of the test suites
+func Zwallet(subcommand string) *Command {
+	return NewCommand("./zwallet", subcommand).WithConfigDir("./config")
+}
+
+func (c *Command) WithArgs(args ...string) *Command {
+	c.Args = append(c.Args, args...)
+	return c
+}
+
+func (c *Command) WithFlag(name string, value interface{}) *Command {
+	c.Flags[name] = value
+	return c
+}
+
+func (c *Command) WithFlags(flags map[string]interface{}) *Command {
+	for name, value := range flags {
+		c.Flags[name] = value
+	}
+	return c
+}
+
+// WithWallet sets the wallet file, relative to the config dir
+func (c *Command) WithWallet(walletFile string) *Command {
+	c.Wallet = walletFile
+	return c
+}
+
+// WithConfig sets the config file, relative to the config dir
+func (c *Command) WithConfig(configFile string) *Command {
+	c.Config = configFile
+	return c
+}
+
+func (c *Command) WithConfigDir(configDir string) *Command {
+	c.ConfigDir = configDir
+	return c
+}
+
+func (c *Command) WithSilent() *Command {
+	c.Silent = true
+	return c
+}
+
+// Argv returns the binary followed by all arguments of the command.
+func (c *Command) Argv() []string {
+	argv := []string{c.Binary}
+	if c.Subcommand != "" {
+		argv = append(argv, c.Subcommand)
+	}
+	argv = append(argv, c.Args...)
+	argv = append(argv, c.Flags.Args()...)
+	if c.Silent {
+		argv = append(argv, "--silent")
+	}
+	if c.Wallet != "" {
+		argv = append(argv, "--wallet", c.Wallet)
+	}
+	if c.ConfigDir != "" {
+		argv = append(argv, "--configDir", c.ConfigDir)
+	}
+	if c.Config != "" {
+		argv = append(argv, "--config", c.Config)
+	}
+	return argv
+}
+
+// String returns the command as it would be typed in a shell, used for logging.
+func (c *Command) String() string {
+	return quoteArgs(c.Argv())
+}
+
+// Run runs the command, retrying transient failures up to maxAttempts times, see RunCommand.
+func (c *Command) Run(t *test.SystemTest, maxAttempts int, backoff time.Duration) ([]string, error) {
+	result, err := c.ExecuteWithRetry(t, maxAttempts, backoff)
+	return result.Sanitized(), err
+}
+
+func (c *Command) RunWithPolicy(t *test.SystemTest, policy RetryPolicy) ([]string, error) {
+	result, err := executeWithRetry(t, c.String(), c.Argv(), policy)
+	return result.Sanitized(), err
+}
+
+// RunWithoutRetry runs the command once, killing its whole process group if ctx is done before it exits.
+func (c *Command) RunWithoutRetry(ctx context.Context) ([]string, error) {
+	return runArgv(ctx, c.String(), c.Argv())
+}
+
+// Execute runs the command once, keeping stdout, stderr and the exit code apart.
+func (c *Command) Execute(ctx context.Context) (*CommandResult, error) {
+	return executeArgv(ctx, c.String(), c.Argv())
+}
+
+// ExecuteWithRetry runs the command, retrying transient failures up to maxAttempts times, and returns the result of the last attempt.
+func (c *Command) ExecuteWithRetry(t *test.SystemTest, maxAttempts int, backoff time.Duration) (*CommandResult, error) {
+	return executeWithRetry(t, c.String(), c.Argv(), retryPolicyFor(t, maxAttempts, backoff))
+}
+
+func quoteArgs(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\n\"'") {
+			arg = strconv.Quote(arg)
+		}
+		quoted = append(quoted, arg)
+	}
+	return strings.Join(quoted, " ")
+}
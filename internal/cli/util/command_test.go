@@ -0,0 +1,44 @@
+package cliutils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlagsArgs(t *testing.T) {
+	flags := Flags{"size": 1024, "name": "file with spaces.txt", "json": nil, "commit": true, "encrypt": false}
+	require.Equal(t,
+		[]string{"--commit=true", "--encrypt=false", "--json", "--name", "file with spaces.txt", "--size", "1024"},
+		flags.Args())
+	require.Equal(t, `--commit=true --encrypt=false --json --name "file with spaces.txt" --size 1024`, flags.String())
+}
+
+func TestCommandArgv(t *testing.T) {
+	tests := map[string]struct {
+		command *Command
+		want    []string
+	}{
+		"without options": {
+			NewCommand("./zwallet", "getnonce"),
+			[]string{"./zwallet", "getnonce"},
+		},
+		"suite conventions": {
+			Zbox("upload").WithFlags(Flags{"allocation": "abc", "remotepath": "/dir/"}).WithSilent().WithWallet("wallet.json").WithConfig("config.yaml"),
+			[]string{"./zbox", "upload", "--allocation", "abc", "--remotepath", "/dir/", "--silent", "--wallet", "wallet.json", "--configDir", "./config", "--config", "config.yaml"},
+		},
+		"positional arguments before flags": {
+			NewCommand("./zwallet", "send").WithArgs("positional").WithFlag("tokens", 0.5),
+			[]string{"./zwallet", "send", "positional", "--tokens", "0.5"},
+		},
+		"quotes are kept": {
+			NewCommand("./zbox", "share").WithFlag("remotepath", `/"quoted" name`),
+			[]string{"./zbox", "share", "--remotepath", `/"quoted" name`},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, tt.command.Argv())
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package cliutils
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -24,6 +26,9 @@ type ChainHistory struct {
 	providerRewards []model.RewardProvider
 	transactions    []model.EventDBTransaction
 	roundHistories  map[int64]RoundHistory
+	// hasTransactions is whether transactions, and so the fees of each round, were read
+	hasTransactions bool
+	cache           *HistoryCache
 }
 
 type RoundHistory struct {
@@ -40,6 +45,12 @@ func NewHistory(from, to int64) *ChainHistory {
 	}
 }
 
+// WithCache reads finalized rounds through the cache, a nil cache reads every round from the sharder
+func (ch *ChainHistory) WithCache(cache *HistoryCache) *ChainHistory {
+	ch.cache = cache
+	return ch
+}
+
 func (ch *ChainHistory) RoundHistory(t *test.SystemTest, round int64) RoundHistory {
 	require.NotNil(t, ch.roundHistories, "round histories' nil, expected to be not nil"+
 		" histories for round %v not found", round)
@@ -79,46 +90,124 @@ func (ch *ChainHistory) FeesForRound(t *test.SystemTest, round int64) int64 {
 	return fee
 }
 
-func (ch *ChainHistory) Read(t *test.SystemTest, sharderBaseUrl string, includeTransactions bool) {
-	ch.readBlocks(t, sharderBaseUrl)
-	ch.readDelegateRewards(t, sharderBaseUrl)
-	ch.readProviderRewards(t, sharderBaseUrl)
-	if includeTransactions {
-		ch.readTransaction(t, sharderBaseUrl)
+// Read reads the history from the sharders with their read strategy. FirstSuccess uses the history of the first
+// sharder it can be read from. With Majority or AllAgree, the history is read from every sharder and the rounds of
+// sharders whose event databases diverge are reported field by field.
+func (ch *ChainHistory) Read(t *test.SystemTest, sharders Sharders, includeTransactions bool) {
+	require.NotEmpty(t, sharders.URLs, "no sharders to read history from")
+
+	histories := make(map[string]*ChainHistory, len(sharders.URLs))
+	bodies := make(map[string]interface{}, len(sharders.URLs))
+	errs := make(map[string]error)
+	for _, sharder := range sharders.URLs {
+		h := NewHistory(ch.from, ch.to)
+		h.cache = ch.cache
+		if h.cache != nil && h.cache.sharder != sharder {
+			h.cache = OpenHistoryCache(t, sharder)
+		}
+		if err := h.read(t, sharder, includeTransactions); err != nil {
+			t.Logf("Reading history of rounds %d to %d from [%s] failed: %v", ch.from, ch.to, sharder, err)
+			errs[sharder] = err
+			continue
+		}
+		histories[sharder] = h
+
+		body, err := h.comparable()
+		require.NoError(t, err, "comparing history of %s", sharder)
+		bodies[sharder] = body
+		if sharders.Strategy == FirstSuccess {
+			break
+		}
 	}
+
+	request := fmt.Sprintf("history of rounds %d to %d", ch.from, ch.to)
+	report, err := chooseResponse(sharders, request, bodies, errs)
+	require.NoError(t, err)
+	if report.Divergent() {
+		t.Logf("Sharders diverge: %s", report)
+	}
+
+	chosen := histories[report.Chosen]
+	ch.blocks = chosen.blocks
+	ch.DelegateRewards = chosen.DelegateRewards
+	ch.providerRewards = chosen.providerRewards
+	ch.transactions = chosen.transactions
+	ch.hasTransactions = chosen.hasTransactions
 	ch.setup(t)
 }
 
-func (ch *ChainHistory) readBlocks(t *test.SystemTest, sharderBaseUrl string) {
+// read fetches the history from a sharder, returning the first error instead of failing the test
+func (ch *ChainHistory) read(t *test.SystemTest, sharderBaseUrl string, includeTransactions bool) error {
+	if err := ch.readBlocks(t, sharderBaseUrl); err != nil {
+		return err
+	}
+	if err := ch.readDelegateRewards(t, sharderBaseUrl); err != nil {
+		return err
+	}
+	if err := ch.readProviderRewards(t, sharderBaseUrl); err != nil {
+		return err
+	}
+	if includeTransactions {
+		if err := ch.readTransaction(t, sharderBaseUrl); err != nil {
+			return err
+		}
+		ch.hasTransactions = true
+	}
+	return nil
+}
+
+// comparable returns the history decoded as generic JSON, for DiffJSON
+func (ch *ChainHistory) comparable() (interface{}, error) {
+	raw, err := json.Marshal(map[string]interface{}{
+		"blocks":           ch.blocks,
+		"delegate_rewards": ch.DelegateRewards,
+		"provider_rewards": ch.providerRewards,
+		"transactions":     ch.transactions,
+	})
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var body interface{}
+	err = decoder.Decode(&body)
+	return body, err
+}
+
+func (ch *ChainHistory) readBlocks(t *test.SystemTest, sharderBaseUrl string) (err error) {
 	url := fmt.Sprintf(sharderBaseUrl + "/v1/screst/" + StorageScAddress + "/get_blocks")
 	params := map[string]string{
 		"contents": "full",
 	}
-	ch.blocks = ApiGetList[model.EventDBBlock](t, url, params, ch.from, ch.to+1)
+	ch.blocks, err = cachedList(t, ch.cache, url, params, ch.from, ch.to+1, func(b *model.EventDBBlock) int64 { return b.Round })
+	return err
 }
 
-func (ch *ChainHistory) readDelegateRewards(t *test.SystemTest, sharderBaseUrl string) {
+func (ch *ChainHistory) readDelegateRewards(t *test.SystemTest, sharderBaseUrl string) (err error) {
 	url := fmt.Sprintf(sharderBaseUrl + "/v1/screst/" + MinerScAddress + "/delegate-rewards")
 	params := map[string]string{
 		"start": strconv.FormatInt(ch.from, 10),
 		"end":   strconv.FormatInt(ch.to+1, 10),
 	}
-	ch.DelegateRewards = ApiGetList[model.RewardDelegate](t, url, params, ch.from, ch.to+1)
+	ch.DelegateRewards, err = cachedList(t, ch.cache, url, params, ch.from, ch.to+1, func(r *model.RewardDelegate) int64 { return r.BlockNumber })
+	return err
 }
 
-func (ch *ChainHistory) readProviderRewards(t *test.SystemTest, sharderBaseUrl string) {
+func (ch *ChainHistory) readProviderRewards(t *test.SystemTest, sharderBaseUrl string) (err error) {
 	url := fmt.Sprintf(sharderBaseUrl + "/v1/screst/" + MinerScAddress + "/provider-rewards")
 	params := map[string]string{
 		"start": strconv.FormatInt(ch.from, 10),
 		"end":   strconv.FormatInt(ch.to+1, 10),
 	}
-	ch.providerRewards = ApiGetList[model.RewardProvider](t, url, params, ch.from, ch.to+1)
+	ch.providerRewards, err = cachedList(t, ch.cache, url, params, ch.from, ch.to+1, func(r *model.RewardProvider) int64 { return r.BlockNumber })
+	return err
 }
 
-func (ch *ChainHistory) readTransaction(t *test.SystemTest, sharderBaseUrl string) {
+func (ch *ChainHistory) readTransaction(t *test.SystemTest, sharderBaseUrl string) (err error) {
 	url := fmt.Sprintf(sharderBaseUrl + "/v1/screst/" + StorageScAddress + "/transactions")
 	params := map[string]string{}
-	ch.transactions = ApiGetList[model.EventDBTransaction](t, url, params, ch.from, ch.to+1)
+	ch.transactions, err = cachedList(t, ch.cache, url, params, ch.from, ch.to+1, func(tx *model.EventDBTransaction) int64 { return tx.Round })
+	return err
 }
 
 func (ch *ChainHistory) setup(t *test.SystemTest) { // nolint:
@@ -164,11 +253,11 @@ func (ch *ChainHistory) setup(t *test.SystemTest) { // nolint:
 		}
 		currentHistory.DelegateRewards = append(currentHistory.DelegateRewards, dr)
 	}
-	ch.setupTransactions(t)
-
 	if currentRound > 0 {
 		ch.roundHistories[currentRound] = currentHistory
 	}
+
+	ch.setupTransactions(t)
 	require.Equalf(t, int(ch.to-ch.from+1), len(ch.roundHistories),
 		"mismatched round count recorded, from %d, to %d", ch.to, ch.from)
 }
@@ -189,6 +278,9 @@ func (ch *ChainHistory) setupTransactions(t *test.SystemTest) {
 		}
 		currentHistory.Transactions = append(currentHistory.Transactions, ch.transactions[i])
 	}
+	if currentRound > 0 {
+		ch.roundHistories[currentRound] = currentHistory
+	}
 }
 
 // debug dumps
@@ -0,0 +1,206 @@
+package cliutils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+
+	"github.com/0chain/system_test/internal/cli/model"
+)
+
+// HistoryCacheDirEnv contains name of env variable with the directory ChainHistory reads are cached in
+const HistoryCacheDirEnv = "HISTORY_CACHE_DIR"
+
+// HistoryCacheOffEnv contains name of env variable which, when set to true, turns the ChainHistory cache off
+const HistoryCacheOffEnv = "HISTORY_CACHE_OFF"
+
+const (
+	defaultHistoryCacheDir = "history_cache"
+	// historyChunkRounds is the number of rounds cached together in one file
+	historyChunkRounds = 100
+	// historyFinalityLag is how many rounds behind the latest finalized round the event database may still be written
+	historyFinalityLag = 20
+	genesisFileName    = "genesis"
+)
+
+var unsafePathChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// HistoryCache stores blocks, rewards and transactions of finalized rounds read from a sharder on disk, keyed by
+// sharder, endpoint and round range, so ChainHistory only fetches rounds it has not read before, across tests and runs.
+// The cache of a sharder is invalidated when the hash of the genesis block changes, i.e. the chain was redeployed.
+type HistoryCache struct {
+	// sharder is the base URL of the sharder the cache is for
+	sharder string
+	dir     string
+	// lastCachable is the last round which is finalized long enough to be cached
+	lastCachable int64
+}
+
+// OpenHistoryCache opens the cache of the sharder, in the directory set by HISTORY_CACHE_DIR.
+// It returns nil, which reads without caching, if the cache is turned off or the chain cannot be identified.
+func OpenHistoryCache(t *test.SystemTest, sharderBaseUrl string) *HistoryCache {
+	if strings.EqualFold(strings.TrimSpace(os.Getenv(HistoryCacheOffEnv)), "true") {
+		return nil
+	}
+	root := os.Getenv(HistoryCacheDirEnv)
+	if root == "" {
+		root = defaultHistoryCacheDir
+	}
+
+	genesis, err := ApiGetError[model.Block](Sharder(sharderBaseUrl), "/v1/block/get", map[string]string{"round": "1", "content": "full"})
+	if err != nil || genesis.Block.Hash == "" {
+		t.Logf("Reading chain history without cache, genesis block of [%s] not found: %v", sharderBaseUrl, err)
+		return nil
+	}
+	latest, err := ApiGetError[model.LatestFinalizedBlock](Sharder(sharderBaseUrl), "/v1/block/get/latest_finalized", nil)
+	if err != nil {
+		t.Logf("Reading chain history without cache, latest finalized block of [%s] not found: %v", sharderBaseUrl, err)
+		return nil
+	}
+
+	c := &HistoryCache{
+		sharder:      sharderBaseUrl,
+		dir:          filepath.Join(root, unsafePathChars.ReplaceAllString(sharderBaseUrl, "_")),
+		lastCachable: latest.Round - historyFinalityLag,
+	}
+	if err := c.checkGenesis(genesis.Block.Hash); err != nil {
+		t.Logf("Reading chain history without cache: %v", err)
+		return nil
+	}
+	return c
+}
+
+// checkGenesis invalidates the cache if it was written for another deployment of the chain
+func (c *HistoryCache) checkGenesis(hash string) error {
+	path := filepath.Join(c.dir, genesisFileName)
+	cached, err := os.ReadFile(path)
+	if err == nil && string(cached) == hash {
+		return nil
+	}
+	if err == nil {
+		if err := c.Invalidate(); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(c.dir, 0755); err != nil { //nolint:gosec
+		return fmt.Errorf("creating history cache [%s]: %w", c.dir, err)
+	}
+	return os.WriteFile(path, []byte(hash), 0644) //nolint:gosec
+}
+
+// Invalidate removes everything cached for the sharder, e.g. after the chain was redeployed
+func (c *HistoryCache) Invalidate() error {
+	if err := os.RemoveAll(c.dir); err != nil {
+		return fmt.Errorf("invalidating history cache [%s]: %w", c.dir, err)
+	}
+	return nil
+}
+
+// chunkPath returns the file caching rounds from to to of the endpoint, excluding to
+func (c *HistoryCache) chunkPath(url string, params map[string]string, from, to int64) string {
+	names := make([]string, 0, len(params))
+	for name := range params {
+		switch name {
+		case "start", "end", "limit", "offset":
+		default:
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	key := url
+	for _, name := range names {
+		key += "&" + name + "=" + params[name]
+	}
+	sum := sha256.Sum256([]byte(key))
+	endpoint := unsafePathChars.ReplaceAllString(filepath.Base(url), "_") + "_" + hex.EncodeToString(sum[:4])
+	return filepath.Join(c.dir, endpoint, strconv.FormatInt(from, 10)+"-"+strconv.FormatInt(to, 10)+".json")
+}
+
+// cachedList reads the items of rounds from to to, excluding to, like ApiGetList. Chunks of finalized rounds are
+// read from the cache, or fetched whole and cached, and the rest is fetched. round returns the round of an item.
+func cachedList[T any](t *test.SystemTest, c *HistoryCache, url string, params map[string]string, from, to int64, round func(*T) int64) ([]T, error) {
+	if c == nil {
+		return apiGetList[T](t.Context(), url, params, from, to)
+	}
+
+	var out []T
+	for chunkFrom := from - from%historyChunkRounds; chunkFrom < to; chunkFrom += historyChunkRounds {
+		chunkTo := chunkFrom + historyChunkRounds
+		if chunkTo-1 > c.lastCachable {
+			fetchFrom := chunkFrom
+			if fetchFrom < from {
+				fetchFrom = from
+			}
+			items, err := apiGetList[T](t.Context(), url, params, fetchFrom, to)
+			return append(out, items...), err
+		}
+
+		items, err := cachedChunk[T](t, c, url, params, chunkFrom, chunkTo)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range items {
+			if r := round(&item); r >= from && r < to {
+				out = append(out, item)
+			}
+		}
+	}
+	return out, nil
+}
+
+// cachedChunk returns the cached items of a chunk of rounds, fetching and caching them if missing
+func cachedChunk[T any](t *test.SystemTest, c *HistoryCache, url string, params map[string]string, from, to int64) ([]T, error) {
+	path := c.chunkPath(url, params, from, to)
+	if raw, err := os.ReadFile(path); err == nil {
+		var items []T
+		if err := json.Unmarshal(raw, &items); err == nil {
+			return items, nil
+		}
+		t.Logf("Ignoring corrupt history cache file [%s]", path)
+	}
+
+	items, err := apiGetList[T](t.Context(), url, params, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeChunk(path, items); err != nil {
+		t.Logf("Failed to cache rounds %d to %d of [%s]: %v", from, to, url, err)
+	}
+	return items, nil
+}
+
+// writeChunk writes the file atomically, as tests running in parallel may read the same chunk
+func writeChunk[T any](path string, items []T) error {
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil { //nolint:gosec
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) //nolint:errcheck
+
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,12 @@
+package cliutils
+
+import (
+	"os"
+	"testing"
+
+	"github.com/0chain/system_test/internal/cli/fake/fakecli"
+)
+
+func TestMain(m *testing.M) {
+	os.Exit(fakecli.Run(m))
+}
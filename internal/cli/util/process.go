@@ -0,0 +1,348 @@
+package cliutils
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/cassette"
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/cli/util/specific"
+)
+
+const (
+	defaultReadyTimeout  = 3 * time.Minute
+	defaultStartAttempts = 3
+	defaultStartBackoff  = 15 * time.Second
+	readyCheckPeriod     = time.Second
+)
+
+// ProcessOptions configures a long-running command started by StartProcess
+type ProcessOptions struct {
+	// Name prefixes the output lines logged to the test, defaults to the name of the binary
+	Name string
+	// Ready matches the output line signalling the process is ready, nil means ready once started
+	Ready *regexp.Regexp
+	// ReadyCount is how many output lines must match Ready, defaults to 1
+	ReadyCount int
+	// ReadyCheck is polled every second as an alternative to Ready, e.g. to wait for files written by the process
+	ReadyCheck func() bool
+	// ReadyTimeout is how long to wait for the ready line, defaults to 3 minutes
+	ReadyTimeout time.Duration
+	// MaxLifetime kills the process once it has run this long, zero means it runs until stopped or the test case ends
+	MaxLifetime time.Duration
+	// StartAttempts is how often starting the process is tried, defaults to 3 like StartCommand
+	StartAttempts int
+	// StartBackoff is the wait between attempts to start the process, defaults to 15 seconds
+	StartBackoff time.Duration
+}
+
+// Process is a long-running command, e.g. a live stream upload, supervised for the test case which started it.
+// Its output is streamed to the test log and its whole process group is killed when the test case ends.
+type Process struct {
+	Command string
+
+	name     string
+	cmd      *exec.Cmd
+	started  time.Time
+	cassette *cassette.Cassette
+
+	stdout, stderr bytes.Buffer
+	combined       lockedBuffer
+
+	ready      chan struct{}
+	readyOnce  sync.Once
+	readyLines int
+	readyCount int
+	done       chan struct{}
+
+	mutex   sync.Mutex
+	stopped bool
+	expired bool
+	result  *CommandResult
+	err     error
+}
+
+// StartProcess starts the command, retrying failed starts, and waits until it writes opts.ReadyCount lines matching
+// opts.Ready or opts.ReadyCheck passes. The process is killed if it is not ready in time.
+func StartProcess(t *test.SystemTest, commandString string, opts ProcessOptions) (*Process, error) {
+	return startProcess(t, commandString, sanitizeArgs(parseCommand(commandString)), opts)
+}
+
+// Start starts the command as a supervised process, see StartProcess.
+func (c *Command) Start(t *test.SystemTest, opts ProcessOptions) (*Process, error) {
+	return startProcess(t, c.String(), c.Argv(), opts)
+}
+
+func startProcess(t *test.SystemTest, commandString string, argv []string, opts ProcessOptions) (*Process, error) {
+	p := &Process{
+		Command:    commandString,
+		name:       opts.Name,
+		ready:      make(chan struct{}),
+		readyCount: opts.ReadyCount,
+		done:       make(chan struct{}),
+	}
+	if p.readyCount == 0 {
+		p.readyCount = 1
+	}
+	if p.name == "" {
+		p.name = strings.TrimSuffix(filepath.Base(argv[0]), ".exe")
+	}
+	if opts.Ready == nil && opts.ReadyCheck == nil {
+		p.markReady()
+	}
+
+	p.cassette = cassette.ForContext(t.Context())
+	if p.cassette != nil && cassette.Replaying() {
+		return p.replay(t)
+	}
+
+	startAttempts, startBackoff := opts.StartAttempts, opts.StartBackoff
+	if startAttempts == 0 {
+		startAttempts = defaultStartAttempts
+	}
+	if startBackoff == 0 {
+		startBackoff = defaultStartBackoff
+	}
+
+	t.Logf("Starting process [%s]", commandString)
+	var stdout, stderr io.Reader
+	for attempt := 1; ; attempt++ {
+		var err error
+		stdout, stderr, err = p.start(argv)
+		if err == nil {
+			if attempt > 1 {
+				t.Logf("Process started on retry [%v/%v].", attempt, startAttempts)
+			}
+			break
+		}
+		if attempt >= startAttempts {
+			t.Logf("Process failed to start on final attempt [%v/%v] due to error [%v].", attempt, startAttempts, err)
+			return nil, fmt.Errorf("failed to start [%s]: %w", commandString, err)
+		}
+		t.Logf("Process failed to start on attempt [%v/%v] due to error [%v]", attempt, startAttempts, err)
+		t.Logf("Sleeping for backoff duration: %v", startBackoff)
+		sleep(t.Context(), startBackoff)
+		if err := t.Context().Err(); err != nil {
+			return nil, err
+		}
+		t.RecordCommandRetry()
+	}
+
+	var streams sync.WaitGroup
+	streams.Add(2)
+	go p.stream(t, &streams, stdout, &p.stdout, "stdout", opts.Ready)
+	go p.stream(t, &streams, stderr, &p.stderr, "stderr", opts.Ready)
+	go func() {
+		// the pipes must be drained before Wait closes them
+		streams.Wait()
+		p.exited(p.cmd.Wait())
+	}()
+
+	go p.supervise(t, opts.MaxLifetime)
+	if opts.ReadyCheck != nil {
+		go p.pollReady(opts.ReadyCheck)
+	}
+	t.Cleanup(func() {
+		if _, err := p.Stop(); err != nil {
+			t.Logf("Process [%s] failed: %v", commandString, err)
+		}
+	})
+
+	readyTimeout := opts.ReadyTimeout
+	if readyTimeout == 0 {
+		readyTimeout = defaultReadyTimeout
+	}
+	timer := time.NewTimer(readyTimeout)
+	defer timer.Stop()
+
+	select {
+	case <-p.ready:
+		return p, nil
+	case <-p.done:
+		result, err := p.Wait()
+		if err == nil {
+			err = errors.New("exited before it was ready")
+		}
+		return p, fmt.Errorf("process [%s] exited with code [%d]: %w", commandString, result.ExitCode, err)
+	case <-timer.C:
+		_, _ = p.Stop()
+		return p, fmt.Errorf("process [%s] was not ready within %v", commandString, readyTimeout)
+	case <-t.Context().Done():
+		_, _ = p.Stop()
+		return p, t.Context().Err()
+	}
+}
+
+// start starts the command in a process group of its own, returning its output pipes
+func (p *Process) start(argv []string) (stdout, stderr io.Reader, err error) {
+	p.cmd = exec.Command(argv[0], argv[1:]...)
+	specific.Setpgid(p.cmd)
+	if stdout, err = p.cmd.StdoutPipe(); err != nil {
+		return nil, nil, err
+	}
+	if stderr, err = p.cmd.StderrPipe(); err != nil {
+		return nil, nil, err
+	}
+	p.started = time.Now()
+	return stdout, stderr, p.cmd.Start()
+}
+
+// replay serves the process from the cassette, as a process which was ready straight away and has exited
+func (p *Process) replay(t *test.SystemTest) (*Process, error) {
+	interaction, err := p.cassette.Replay(cassette.KindProcess, p.Command, "")
+	if err != nil {
+		return nil, err
+	}
+	t.Logf("Replaying process [%s]", p.Command)
+	p.result, p.err = replayedResult(p.Command, interaction)
+	p.markReady()
+	close(p.done)
+	return p, nil
+}
+
+// stream logs each output line with the process name and stream as prefix, and signals readiness on a matching line
+func (p *Process) stream(t *test.SystemTest, streams *sync.WaitGroup, r io.Reader, buffer *bytes.Buffer, kind string, ready *regexp.Regexp) {
+	defer streams.Done()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		buffer.WriteString(line + "\n")
+		_, _ = p.combined.Write([]byte(line + "\n"))
+
+		if trimmed := strings.TrimSpace(line); trimmed != "" {
+			t.Logf("[%s %s] %s", p.name, kind, trimmed)
+		}
+		if ready != nil && ready.MatchString(line) {
+			p.readyLine()
+		}
+	}
+	// keep draining overlong lines, so the process does not block on a full pipe
+	_, _ = io.Copy(io.Discard, r)
+}
+
+// supervise kills the process once its max lifetime is exceeded or the test case ends
+func (p *Process) supervise(t *test.SystemTest, maxLifetime time.Duration) {
+	var expired <-chan time.Time
+	if maxLifetime > 0 {
+		timer := time.NewTimer(maxLifetime)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case <-p.done:
+	case <-expired:
+		p.mutex.Lock()
+		p.expired = !p.stopped
+		p.mutex.Unlock()
+		t.Logf("Killing process [%s] after max lifetime of %v", p.Command, maxLifetime)
+		_ = specific.KillProcessGroup(p.cmd)
+	case <-t.Context().Done():
+		p.mutex.Lock()
+		p.stopped = true
+		p.mutex.Unlock()
+		_ = specific.KillProcessGroup(p.cmd)
+	}
+}
+
+// pollReady marks the process ready once the check passes, unless it exits or is ready before
+func (p *Process) pollReady(check func() bool) {
+	ticker := time.NewTicker(readyCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.ready:
+			return
+		case <-p.done:
+			return
+		case <-ticker.C:
+			if check() {
+				p.markReady()
+				return
+			}
+		}
+	}
+}
+
+// readyLine counts an output line matching Ready, marking the process ready once enough lines matched
+func (p *Process) readyLine() {
+	p.mutex.Lock()
+	p.readyLines++
+	ready := p.readyLines >= p.readyCount
+	p.mutex.Unlock()
+	if ready {
+		p.markReady()
+	}
+}
+
+func (p *Process) markReady() {
+	p.readyOnce.Do(func() { close(p.ready) })
+}
+
+func (p *Process) exited(err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.result = &CommandResult{
+		Command:  p.Command,
+		Stdout:   p.stdout.Bytes(),
+		Stderr:   p.stderr.Bytes(),
+		Combined: p.combined.Bytes(),
+		ExitCode: exitCode(err),
+		Duration: time.Since(p.started),
+	}
+	switch {
+	case p.expired:
+		p.err = errors.New("killed after exceeding its max lifetime")
+	case p.stopped:
+		// killed on purpose, so the kill signal is not an error
+		p.err = nil
+	default:
+		p.err = err
+	}
+	if p.cassette != nil && cassette.Recording() {
+		recordResult(p.cassette, cassette.KindProcess, p.result, p.err)
+	}
+	close(p.done)
+}
+
+// Done is closed once the process has exited and its output has been read
+func (p *Process) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait waits for the process to exit on its own and returns its result.
+// The error is nil if it exited with code 0 or was stopped with Stop.
+func (p *Process) Wait() (*CommandResult, error) {
+	<-p.done
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.result, p.err
+}
+
+// Stop kills the whole process group, unless the process already exited, and returns its result.
+// It is safe to call more than once, and is called when the test case ends.
+func (p *Process) Stop() (*CommandResult, error) {
+	p.mutex.Lock()
+	select {
+	case <-p.done:
+	default:
+		p.stopped = true
+		_ = specific.KillProcessGroup(p.cmd)
+	}
+	p.mutex.Unlock()
+
+	return p.Wait()
+}
@@ -0,0 +1,106 @@
+//go:build !windows
+// +build !windows
+
+package cliutils
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+func shell(script string) *Command {
+	return NewCommand("sh", "").WithArgs("-c", script)
+}
+
+func TestProcessReadiness(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	t.RunSequentially("Ready once enough lines match", func(t *test.SystemTest) {
+		p, err := shell("echo starting; for i in 1 2 3; do echo chunk $i done; done; sleep 30").
+			Start(t, ProcessOptions{Ready: regexp.MustCompile(`chunk \d done`), ReadyCount: 3, ReadyTimeout: 10 * time.Second})
+		require.NoError(t, err)
+		_, err = p.Stop()
+		require.NoError(t, err, "a stopped process must not fail")
+		result, _ := p.Wait()
+		require.Contains(t, string(result.Stdout), "chunk 3 done")
+	})
+
+	t.RunSequentially("Ready once the check passes", func(t *test.SystemTest) {
+		segment := filepath.Join(t.TempDir(), "up0.ts")
+		p, err := shell("sleep 1; touch "+segment+"; sleep 30").
+			Start(t, ProcessOptions{ReadyCheck: func() bool {
+				_, err := os.Stat(segment)
+				return err == nil
+			}, ReadyTimeout: 10 * time.Second})
+		require.NoError(t, err)
+		require.FileExists(t, segment)
+		_, err = p.Stop()
+		require.NoError(t, err, "a stopped process must not fail")
+	})
+
+	t.RunSequentially("Not ready in time", func(t *test.SystemTest) {
+		p, err := shell("echo chunk 1 done; sleep 30").
+			Start(t, ProcessOptions{Ready: regexp.MustCompile(`chunk \d done`), ReadyCount: 2, ReadyTimeout: 200 * time.Millisecond})
+		require.ErrorContains(t, err, "was not ready within")
+		select {
+		case <-p.Done():
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "a process which is not ready in time must be killed")
+		}
+	})
+
+	t.RunSequentially("Exited before ready", func(t *test.SystemTest) {
+		_, err := shell("echo failing >&2; exit 3").
+			Start(t, ProcessOptions{Ready: regexp.MustCompile(`ready`), ReadyTimeout: 10 * time.Second})
+		require.ErrorContains(t, err, "exited with code [3]")
+	})
+}
+
+func TestProcessMaxLifetime(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	p, err := shell("sleep 30").Start(t, ProcessOptions{MaxLifetime: 200 * time.Millisecond})
+	require.NoError(t, err)
+
+	_, err = p.Wait()
+	require.ErrorContains(t, err, "max lifetime")
+}
+
+func TestProcessStopKillsGroup(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	p, err := shell("sleep 30 & echo child $!; wait").
+		Start(t, ProcessOptions{Ready: regexp.MustCompile(`^child \d+$`), ReadyTimeout: 10 * time.Second})
+	require.NoError(t, err)
+
+	result, err := p.Stop()
+	require.NoError(t, err)
+	_, err = p.Stop()
+	require.NoError(t, err, "Stop must be safe to call again")
+
+	pid, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(string(result.Stdout), "child")))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		return syscall.Kill(pid, 0) == syscall.ESRCH
+	}, 5*time.Second, 50*time.Millisecond, "child process [%d] must be killed with its group", pid)
+}
+
+func TestProcessStartRetries(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	started := time.Now()
+	_, err := NewCommand("./missing-binary", "").
+		Start(t, ProcessOptions{StartAttempts: 3, StartBackoff: 100 * time.Millisecond})
+	require.ErrorContains(t, err, "failed to start")
+	require.GreaterOrEqual(t, time.Since(started), 200*time.Millisecond, "a failed start must be retried after the backoff")
+}
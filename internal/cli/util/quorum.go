@@ -0,0 +1,350 @@
+package cliutils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// maxFieldDiffs limits the field differences reported for each divergent sharder
+const maxFieldDiffs = 20
+
+// ReadStrategy decides which response is used when reading from a set of sharders
+type ReadStrategy int
+
+const (
+	// FirstSuccess uses the first successful response, trying the sharders in order
+	FirstSuccess ReadStrategy = iota
+	// Majority uses the response body returned by more than half of the sharders
+	Majority
+	// AllAgree requires all sharders to return the same response body
+	AllAgree
+)
+
+func (s ReadStrategy) String() string {
+	switch s {
+	case FirstSuccess:
+		return "first-success"
+	case Majority:
+		return "majority"
+	case AllAgree:
+		return "all-agree"
+	default:
+		return fmt.Sprintf("ReadStrategy(%d)", int(s))
+	}
+}
+
+// Sharders is a set of sharder base URLs read with a strategy
+type Sharders struct {
+	URLs     []string
+	Strategy ReadStrategy
+}
+
+// Sharder reads from a single sharder
+func Sharder(baseURL string) Sharders {
+	return Sharders{URLs: []string{baseURL}}
+}
+
+// QuorumReport lists the sharders whose responses diverge from the one used, with the differing fields
+type QuorumReport struct {
+	Strategy ReadStrategy
+	Request  string
+	// Chosen is the sharder whose response is used, empty if there is none
+	Chosen string
+	// Agreeing are the sharders which returned the same response as Chosen
+	Agreeing []string
+	// Diffs are the fields in which the response of each divergent sharder differs from the one used
+	Diffs map[string][]string
+	// Errors are the sharders which failed to respond
+	Errors map[string]error
+}
+
+// Divergent reports whether any sharder failed or returned a different response
+func (r *QuorumReport) Divergent() bool {
+	return len(r.Diffs) > 0 || len(r.Errors) > 0
+}
+
+func (r *QuorumReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s read of [%s]: %d sharders agree with [%s]", r.Strategy, r.Request, len(r.Agreeing), r.Chosen)
+	for _, sharder := range sortedKeys(r.Errors) {
+		fmt.Fprintf(&b, "\n  %s failed: %v", sharder, r.Errors[sharder])
+	}
+	for _, sharder := range sortedKeys(r.Diffs) {
+		fmt.Fprintf(&b, "\n  %s diverges:", sharder)
+		for _, diff := range r.Diffs[sharder] {
+			fmt.Fprintf(&b, "\n    %s", diff)
+		}
+	}
+	return b.String()
+}
+
+// QuorumError is returned when the responses of the sharders do not satisfy the read strategy
+type QuorumError struct {
+	Report *QuorumReport
+}
+
+func (e *QuorumError) Error() string {
+	return "no quorum, " + e.Report.String()
+}
+
+// ApiGet reads the path, e.g. "/v1/screst/<sc>/getMinerList", from the sharders with their read strategy.
+// Divergent responses are logged with the fields in which they differ.
+func ApiGet[T any](t *test.SystemTest, sharders Sharders, path string, params map[string]string) *T {
+	result, report, err := apiGetQuorum[T](t.Context(), sharders, path, params)
+	require.NoError(t, err)
+	if report.Divergent() {
+		t.Logf("Sharders diverge: %s", report)
+	}
+	return result
+}
+
+// ApiGetRetries is ApiGet retrying failed reads, e.g. reads without quorum while the event databases catch up
+func ApiGetRetries[T any](t *test.SystemTest, sharders Sharders, path string, params map[string]string, retries int) *T {
+	var err error
+	var res *T
+	var report *QuorumReport
+	for try := 1; try <= retries; try++ {
+		res, report, err = apiGetQuorum[T](t.Context(), sharders, path, params)
+		if err != nil {
+			t.Logf("retry %d, %v", try, err)
+		} else {
+			break
+		}
+	}
+	assert.NoError(t, err, "%s failed after %d retries", path, retries)
+	if err == nil && report.Divergent() {
+		t.Logf("Sharders diverge: %s", report)
+	}
+	return res
+}
+
+// ApiGetError is ApiGet returning a *QuorumError if the responses do not satisfy the read strategy
+func ApiGetError[T any](sharders Sharders, path string, params map[string]string) (*T, error) {
+	result, report, err := apiGetQuorum[T](context.Background(), sharders, path, params)
+	if err == nil && report.Divergent() {
+		log.Printf("Sharders diverge: %s", report)
+	}
+	return result, err
+}
+
+func apiGetQuorum[T any](ctx context.Context, sharders Sharders, path string, params map[string]string) (*T, *QuorumReport, error) {
+	request := addParms(path, params)
+	if len(sharders.URLs) == 0 {
+		return nil, nil, fmt.Errorf("no sharders to read [%s] from", request)
+	}
+
+	var bodies map[string]interface{}
+	var errs map[string]error
+	if sharders.Strategy == FirstSuccess {
+		bodies, errs = make(map[string]interface{}), make(map[string]error)
+		for _, sharder := range sharders.URLs {
+			body, err := getJSON(ctx, sharder+request)
+			if err == nil {
+				bodies[sharder] = body
+				break
+			}
+			errs[sharder] = err
+		}
+	} else {
+		bodies, errs = getJSONFromAll(ctx, sharders.URLs, request)
+	}
+
+	report, err := chooseResponse(sharders, request, bodies, errs)
+	if err != nil {
+		return nil, report, err
+	}
+
+	raw, err := json.Marshal(bodies[report.Chosen])
+	if err != nil {
+		return nil, report, err
+	}
+	var result = new(T)
+	if err := json.Unmarshal(raw, result); err != nil {
+		return nil, report, fmt.Errorf("deserializing JSON string `%s`: %v", string(raw), err)
+	}
+	return result, report, nil
+}
+
+// chooseResponse picks the response of the largest group of sharders returning identical bodies, and checks the group
+// satisfies the read strategy
+func chooseResponse(sharders Sharders, request string, bodies map[string]interface{}, errs map[string]error) (*QuorumReport, error) {
+	report := &QuorumReport{
+		Strategy: sharders.Strategy,
+		Request:  request,
+		Diffs:    make(map[string][]string),
+		Errors:   errs,
+	}
+
+	var groups [][]string
+	for _, sharder := range sharders.URLs {
+		body, ok := bodies[sharder]
+		if !ok {
+			continue
+		}
+		found := false
+		for i, group := range groups {
+			if reflect.DeepEqual(bodies[group[0]], body) {
+				groups[i] = append(group, sharder)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, []string{sharder})
+		}
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return len(groups[i]) > len(groups[j]) })
+	if len(groups) == 0 {
+		return report, &QuorumError{Report: report}
+	}
+
+	report.Chosen = groups[0][0]
+	report.Agreeing = groups[0]
+	for _, group := range groups[1:] {
+		for _, sharder := range group {
+			report.Diffs[sharder] = DiffJSON(bodies[report.Chosen], bodies[sharder])
+		}
+	}
+
+	switch sharders.Strategy {
+	case Majority:
+		if 2*len(report.Agreeing) <= len(sharders.URLs) {
+			return report, &QuorumError{Report: report}
+		}
+	case AllAgree:
+		if len(report.Agreeing) != len(sharders.URLs) {
+			return report, &QuorumError{Report: report}
+		}
+	}
+	return report, nil
+}
+
+func getJSONFromAll(ctx context.Context, urls []string, request string) (map[string]interface{}, map[string]error) {
+	bodies := make(map[string]interface{})
+	errs := make(map[string]error)
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for _, sharder := range urls {
+		wg.Add(1)
+		go func(sharder string) {
+			defer wg.Done()
+			body, err := getJSON(ctx, sharder+request)
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs[sharder] = err
+			} else {
+				bodies[sharder] = body
+			}
+		}(sharder)
+	}
+	wg.Wait()
+	return bodies, errs
+}
+
+// getJSON returns the decoded body of a successful response, so bodies can be compared regardless of field order
+func getJSON(ctx context.Context, url string) (interface{}, error) {
+	res, err := httpGet(ctx, url)
+	if err != nil {
+		return nil, fmt.Errorf("with request %s, %v", url, err)
+	}
+	defer res.Body.Close()
+
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("response %s, reading response body: %v", url, err)
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed API request %s, status code: %d", url, res.StatusCode)
+	}
+
+	// numbers are kept as written, so large amounts survive decoding the body again into its type
+	decoder := json.NewDecoder(bytes.NewReader(resBody))
+	decoder.UseNumber()
+	var body interface{}
+	if err := decoder.Decode(&body); err != nil {
+		return nil, fmt.Errorf("deserializing JSON string `%s`: %v", string(resBody), err)
+	}
+	return body, nil
+}
+
+// DiffJSON lists the fields in which decoded JSON values differ, e.g. "$.nodes[0].total_stake: 10 != 12",
+// at most maxFieldDiffs of them.
+func DiffJSON(expected, actual interface{}) []string {
+	var diffs []string
+	diffJSON("$", expected, actual, &diffs)
+	if len(diffs) > maxFieldDiffs {
+		// diffing stops once the limit is exceeded, so the number of further diffs is unknown
+		diffs = append(diffs[:maxFieldDiffs], "... more fields differ")
+	}
+	return diffs
+}
+
+func diffJSON(path string, expected, actual interface{}, diffs *[]string) {
+	if len(*diffs) > maxFieldDiffs {
+		return
+	}
+	switch e := expected.(type) {
+	case map[string]interface{}:
+		a, ok := actual.(map[string]interface{})
+		if !ok {
+			break
+		}
+		names := make(map[string]bool, len(e)+len(a))
+		for name := range e {
+			names[name] = true
+		}
+		for name := range a {
+			names[name] = true
+		}
+		for _, name := range sortedKeys(names) {
+			ev, inExpected := e[name]
+			av, inActual := a[name]
+			switch {
+			case !inActual:
+				*diffs = append(*diffs, fmt.Sprintf("%s.%s: missing", path, name))
+			case !inExpected:
+				*diffs = append(*diffs, fmt.Sprintf("%s.%s: unexpected %s", path, name, jsonString(av)))
+			default:
+				diffJSON(path+"."+name, ev, av, diffs)
+			}
+		}
+		return
+	case []interface{}:
+		a, ok := actual.([]interface{})
+		if !ok {
+			break
+		}
+		if len(e) != len(a) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: length %d != %d", path, len(e), len(a)))
+		}
+		for i := 0; i < len(e) && i < len(a); i++ {
+			diffJSON(fmt.Sprintf("%s[%d]", path, i), e[i], a[i], diffs)
+		}
+		return
+	}
+
+	if !reflect.DeepEqual(expected, actual) {
+		*diffs = append(*diffs, fmt.Sprintf("%s: %s != %s", path, jsonString(expected), jsonString(actual)))
+	}
+}
+
+func jsonString(value interface{}) string {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(raw)
+}
@@ -0,0 +1,249 @@
+package cliutils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/cli/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChooseResponse(t *testing.T) {
+	urls := []string{"a", "b", "c"}
+	one := map[string]interface{}{"value": json.Number("1")}
+	two := map[string]interface{}{"value": json.Number("2")}
+	three := map[string]interface{}{"value": json.Number("3")}
+	failed := errors.New("connection refused")
+
+	tests := map[string]struct {
+		strategy     ReadStrategy
+		bodies       map[string]interface{}
+		errs         map[string]error
+		wantChosen   string
+		wantAgreeing []string
+		wantDiffs    map[string][]string
+		wantErr      bool
+	}{
+		"first success": {
+			strategy:     FirstSuccess,
+			bodies:       map[string]interface{}{"b": one},
+			errs:         map[string]error{"a": failed},
+			wantChosen:   "b",
+			wantAgreeing: []string{"b"},
+		},
+		"no responses": {
+			strategy: FirstSuccess,
+			errs:     map[string]error{"a": failed, "b": failed, "c": failed},
+			wantErr:  true,
+		},
+		"majority agrees": {
+			strategy:     Majority,
+			bodies:       map[string]interface{}{"a": two, "b": one, "c": one},
+			wantChosen:   "b",
+			wantAgreeing: []string{"b", "c"},
+			wantDiffs:    map[string][]string{"a": {"$.value: 1 != 2"}},
+		},
+		"majority despite a failed sharder": {
+			strategy:     Majority,
+			bodies:       map[string]interface{}{"a": one, "c": one},
+			errs:         map[string]error{"b": failed},
+			wantChosen:   "a",
+			wantAgreeing: []string{"a", "c"},
+		},
+		"no majority among divergent sharders": {
+			strategy:     Majority,
+			bodies:       map[string]interface{}{"a": one, "b": two, "c": three},
+			wantChosen:   "a",
+			wantAgreeing: []string{"a"},
+			wantDiffs:    map[string][]string{"b": {"$.value: 1 != 2"}, "c": {"$.value: 1 != 3"}},
+			wantErr:      true,
+		},
+		"no majority among failed sharders": {
+			strategy:     Majority,
+			bodies:       map[string]interface{}{"a": one},
+			errs:         map[string]error{"b": failed, "c": failed},
+			wantChosen:   "a",
+			wantAgreeing: []string{"a"},
+			wantErr:      true,
+		},
+		"all agree": {
+			strategy:     AllAgree,
+			bodies:       map[string]interface{}{"a": one, "b": one, "c": one},
+			wantChosen:   "a",
+			wantAgreeing: []string{"a", "b", "c"},
+		},
+		"not all agree": {
+			strategy:     AllAgree,
+			bodies:       map[string]interface{}{"a": one, "b": one, "c": two},
+			wantChosen:   "a",
+			wantAgreeing: []string{"a", "b"},
+			wantDiffs:    map[string][]string{"c": {"$.value: 1 != 2"}},
+			wantErr:      true,
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			report, err := chooseResponse(Sharders{URLs: urls, Strategy: tt.strategy}, "/path", tt.bodies, tt.errs)
+			if tt.wantErr {
+				var quorumErr *QuorumError
+				require.ErrorAs(t, err, &quorumErr)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.wantChosen, report.Chosen)
+			require.Equal(t, tt.wantAgreeing, report.Agreeing)
+			if tt.wantDiffs == nil {
+				tt.wantDiffs = map[string][]string{}
+			}
+			require.Equal(t, tt.wantDiffs, report.Diffs)
+			require.Equal(t, len(tt.errs) > 0 || len(tt.wantDiffs) > 0, report.Divergent())
+		})
+	}
+}
+
+func TestDiffJSON(t *testing.T) {
+	decode := func(raw string) interface{} {
+		var value interface{}
+		require.NoError(t, json.Unmarshal([]byte(raw), &value))
+		return value
+	}
+	many := map[string]interface{}{}
+	for i := 0; i < maxFieldDiffs+5; i++ {
+		many[fmt.Sprintf("f%02d", i)] = float64(i)
+	}
+	manyChanged := map[string]interface{}{}
+	for name := range many {
+		manyChanged[name] = -1.0
+	}
+
+	tests := map[string]struct {
+		expected, actual interface{}
+		want             []string
+	}{
+		"equal": {
+			expected: decode(`{"a":1,"b":[1,2],"c":{"d":"x"}}`),
+			actual:   decode(`{"c":{"d":"x"},"b":[1,2],"a":1}`),
+		},
+		"changed value": {
+			expected: decode(`{"nodes":[{"total_stake":10}]}`),
+			actual:   decode(`{"nodes":[{"total_stake":12}]}`),
+			want:     []string{"$.nodes[0].total_stake: 10 != 12"},
+		},
+		"missing and unexpected fields": {
+			expected: decode(`{"a":1,"b":2}`),
+			actual:   decode(`{"b":2,"c":{"d":true}}`),
+			want:     []string{"$.a: missing", `$.c: unexpected {"d":true}`},
+		},
+		"array length": {
+			expected: decode(`{"a":[1,2,3]}`),
+			actual:   decode(`{"a":[1,5]}`),
+			want:     []string{"$.a: length 3 != 2", "$.a[1]: 2 != 5"},
+		},
+		"type changed": {
+			expected: decode(`{"a":{"b":1}}`),
+			actual:   decode(`{"a":[1]}`),
+			want:     []string{`$.a: {"b":1} != [1]`},
+		},
+		"limited number of diffs": {
+			expected: many,
+			actual:   manyChanged,
+			want: func() []string {
+				var want []string
+				for i := 0; i < maxFieldDiffs; i++ {
+					want = append(want, fmt.Sprintf("$.f%02d: %d != -1", i, i))
+				}
+				return append(want, "... more fields differ")
+			}(),
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, DiffJSON(tt.expected, tt.actual))
+		})
+	}
+}
+
+type balance struct {
+	Balance int64 `json:"balance"`
+}
+
+func TestApiGetFromSharders(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	respond := func(status int, body string) string {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+		t.Cleanup(server.Close)
+		return server.URL
+	}
+	failing := respond(http.StatusInternalServerError, "failed")
+	large := respond(http.StatusOK, `{"balance":1234567890123456789}`)
+	sameLarge := []string{
+		respond(http.StatusOK, `{"balance":1234567890123456789}`),
+		respond(http.StatusOK, `{"balance":1234567890123456789}`),
+	}
+	other := respond(http.StatusOK, `{"balance":1}`)
+
+	got := ApiGet[balance](t, Sharders{URLs: []string{failing, large}}, "/balance", nil)
+	require.Equal(t, int64(1234567890123456789), got.Balance, "FirstSuccess must fall back to the next sharder and keep large numbers")
+
+	got = ApiGet[balance](t, Sharders{URLs: append([]string{other, large, failing}, sameLarge...), Strategy: Majority}, "/balance", nil)
+	require.Equal(t, int64(1234567890123456789), got.Balance)
+
+	_, err := ApiGetError[balance](Sharders{URLs: []string{large, other}, Strategy: AllAgree}, "/balance", nil)
+	var quorumErr *QuorumError
+	require.ErrorAs(t, err, &quorumErr)
+	require.Equal(t, []string{"$.balance: 1234567890123456789 != 1"}, quorumErr.Report.Diffs[other])
+}
+
+// historyServer serves blocks mined by minerID for every round, and no rewards or transactions
+func historyServer(t *test.SystemTest, minerID string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		start, _ := strconv.ParseInt(query.Get("start"), 10, 64)
+		end, _ := strconv.ParseInt(query.Get("end"), 10, 64)
+		limit, _ := strconv.ParseInt(query.Get("limit"), 10, 64)
+		offset, _ := strconv.ParseInt(query.Get("offset"), 10, 64)
+
+		blocks := []model.EventDBBlock{}
+		if r.URL.Path == "/v1/screst/"+StorageScAddress+"/get_blocks" {
+			for round := start + offset; round < end && round < start+offset+limit; round++ {
+				blocks = append(blocks, model.EventDBBlock{Round: round, MinerID: minerID})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(blocks)
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestChainHistoryReadFromSharders(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "failed", http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	divergent := historyServer(t, "other miner")
+	good := []string{historyServer(t, "miner"), historyServer(t, "miner"), historyServer(t, "miner")}
+
+	t.RunSequentially("First success falls back to the next sharder", func(t *test.SystemTest) {
+		history := NewHistory(10, 30)
+		history.Read(t, Sharders{URLs: []string{failing.URL, good[0]}}, true)
+		require.Equal(t, "miner", history.RoundHistory(t, 30).Block.MinerID)
+	})
+
+	t.RunSequentially("Majority ignores failed and divergent sharders", func(t *test.SystemTest) {
+		history := NewHistory(10, 30)
+		history.Read(t, Sharders{URLs: append([]string{divergent, failing.URL}, good...), Strategy: Majority}, false)
+		require.Equal(t, int64(21), history.TimesWonBestMiner("miner"))
+	})
+}
@@ -0,0 +1,62 @@
+package cliutils
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// CommandResult is the outcome of a single command run.
+// Stdout and Stderr are kept as written by the command, Combined interleaves both in the order they were written.
+type CommandResult struct {
+	Command  string
+	Stdout   []byte
+	Stderr   []byte
+	Combined []byte
+	// ExitCode is -1 if the command could not be started or was killed
+	ExitCode int
+	Duration time.Duration
+}
+
+// StdoutLines returns the lines written to stdout, without any de-duplication
+func (r *CommandResult) StdoutLines() []string {
+	return lines(r.Stdout)
+}
+
+// StderrLines returns the lines written to stderr, without any de-duplication
+func (r *CommandResult) StderrLines() []string {
+	return lines(r.Stderr)
+}
+
+// CombinedLines returns the lines written to stdout and stderr, without any de-duplication
+func (r *CommandResult) CombinedLines() []string {
+	return lines(r.Combined)
+}
+
+// Sanitized returns the trimmed, de-duplicated lines of stdout and stderr, as returned by RunCommand.
+func (r *CommandResult) Sanitized() []string {
+	if r == nil {
+		return nil
+	}
+	return sanitizeOutput(r.Combined)
+}
+
+func lines(output []byte) []string {
+	trimmed := strings.TrimRight(string(output), "\r\n")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(trimmed, "\r\n", "\n"), "\n")
+}
+
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
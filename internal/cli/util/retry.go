@@ -0,0 +1,162 @@
+package cliutils
+
+import (
+	"math/rand"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+// ErrorClass classifies why a command failed, deciding whether retrying it can help
+type ErrorClass string
+
+const (
+	ErrorClassNonceMismatch       ErrorClass = "nonce-mismatch"
+	ErrorClassConsensus           ErrorClass = "consensus-not-reached"
+	ErrorClassTimeout             ErrorClass = "timeout"
+	ErrorClassNetwork             ErrorClass = "network"
+	ErrorClassInsufficientBalance ErrorClass = "insufficient-balance"
+	ErrorClassAuth                ErrorClass = "auth-failure"
+	ErrorClassUnknown             ErrorClass = "unknown"
+)
+
+// ErrorPattern classifies a failed command whose output or error matches the pattern
+type ErrorPattern struct {
+	Class   ErrorClass
+	Pattern *regexp.Regexp
+}
+
+// ErrorPatterns are matched in order, so deterministic failures are listed before transient ones
+var ErrorPatterns = []ErrorPattern{
+	{ErrorClassAuth, regexp.MustCompile(`(?i)unauthori[sz]ed|not authori[sz]ed|only owner|invalid signature|forbidden|status code:? 40[13]\b`)},
+	{ErrorClassInsufficientBalance, regexp.MustCompile(`(?i)insufficient|not enough (balance|tokens)|balance is lower`)},
+	{ErrorClassNonceMismatch, regexp.MustCompile(`(?i)(invalid|mismatch|wrong)[^\n]*nonce|nonce[^\n]*(invalid|mismatch|too (low|high)|lower|higher)`)},
+	{ErrorClassConsensus, regexp.MustCompile(`(?i)consensus`)},
+	{ErrorClassTimeout, regexp.MustCompile(`(?i)timed? ?out|deadline exceeded`)},
+	{ErrorClassNetwork, regexp.MustCompile(`(?i)connection (refused|reset)|no such host|unexpected EOF|bad gateway|service unavailable|too many requests`)},
+}
+
+// ClassifyError returns the class of the first pattern matching the output or the error of a failed command
+func ClassifyError(output []string, err error) ErrorClass {
+	text := strings.Join(output, "\n")
+	if err != nil {
+		text += "\n" + err.Error()
+	}
+	for _, pattern := range ErrorPatterns {
+		if pattern.Pattern.MatchString(text) {
+			return pattern.Class
+		}
+	}
+	return ErrorClassUnknown
+}
+
+// RetryPolicy decides which failed commands are retried and how long to wait before retrying them.
+// The backoff grows exponentially from InitialBackoff up to MaxBackoff, randomised by up to Jitter in either direction.
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Jitter         float64
+	// Retryable lists the error classes worth retrying, all others fail straight away
+	Retryable map[ErrorClass]bool
+}
+
+// TransientErrorClasses are retried by default. Unclassified failures are not, so tests expecting a command to
+// fail do not wait for it to be retried; add a pattern to ErrorPatterns for transient failures not classified yet.
+var TransientErrorClasses = map[ErrorClass]bool{
+	ErrorClassNonceMismatch: true,
+	ErrorClassConsensus:     true,
+	ErrorClassTimeout:       true,
+	ErrorClassNetwork:       true,
+}
+
+// DefaultRetryPolicy is used by RunCommand, retrying transient failures up to maxAttempts times starting with the given backoff
+func DefaultRetryPolicy(maxAttempts int, backoff time.Duration) RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    maxAttempts,
+		InitialBackoff: backoff,
+		MaxBackoff:     8 * backoff,
+		Jitter:         0.2,
+		Retryable:      TransientErrorClasses,
+	}
+}
+
+// ShouldRetry reports whether a failed attempt, counted from 1, is retried
+func (p RetryPolicy) ShouldRetry(attempt int, class ErrorClass) bool {
+	return attempt < p.MaxAttempts && p.Retryable[class]
+}
+
+// Backoff returns how long to wait after the given failed attempt, counted from 1
+func (p RetryPolicy) Backoff(attempt int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || backoff < p.MaxBackoff); i++ {
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		backoff += time.Duration(float64(backoff) * p.Jitter * (2*jitter() - 1))
+	}
+	return backoff
+}
+
+var (
+	jitterMutex sync.Mutex
+	// jitterRand is kept apart from the seeded random source of the test, so retries do not change the values drawn by the test
+	jitterRand = rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+)
+
+func jitter() float64 {
+	jitterMutex.Lock()
+	defer jitterMutex.Unlock()
+	return jitterRand.Float64()
+}
+
+var (
+	retryPoliciesMutex sync.Mutex
+	retryPolicies      = make(map[string]RetryPolicy)
+)
+
+// SetRetryPolicy overrides the retry policy of the commands run by the test and its nested test cases.
+// A zero MaxAttempts or InitialBackoff keeps the values passed to RunCommand.
+func SetRetryPolicy(t *test.SystemTest, policy RetryPolicy) {
+	name := t.Name()
+
+	retryPoliciesMutex.Lock()
+	retryPolicies[name] = policy
+	retryPoliciesMutex.Unlock()
+
+	t.Cleanup(func() {
+		retryPoliciesMutex.Lock()
+		defer retryPoliciesMutex.Unlock()
+		delete(retryPolicies, name)
+	})
+}
+
+// retryPolicyFor returns the policy set for the test or its closest parent, or the default policy
+func retryPolicyFor(t *test.SystemTest, maxAttempts int, backoff time.Duration) RetryPolicy {
+	retryPoliciesMutex.Lock()
+	defer retryPoliciesMutex.Unlock()
+
+	for name := t.Name(); name != ""; {
+		if policy, ok := retryPolicies[name]; ok {
+			if policy.MaxAttempts == 0 {
+				policy.MaxAttempts = maxAttempts
+			}
+			if policy.InitialBackoff == 0 {
+				policy.InitialBackoff = backoff
+			}
+			return policy
+		}
+		i := strings.LastIndex(name, "/")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+	return DefaultRetryPolicy(maxAttempts, backoff)
+}
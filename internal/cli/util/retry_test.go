@@ -0,0 +1,73 @@
+package cliutils
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 4 * time.Second}
+	for attempt, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 4 * time.Second} {
+		if got := policy.Backoff(attempt + 1); got != want {
+			t.Errorf("attempt %d: expected backoff of %v, got %v", attempt+1, want, got)
+		}
+	}
+
+	policy.Jitter = 0.2
+	for attempt := 1; attempt <= 4; attempt++ {
+		base := RetryPolicy{InitialBackoff: policy.InitialBackoff, MaxBackoff: policy.MaxBackoff}.Backoff(attempt)
+		got := policy.Backoff(attempt)
+		if got < base*8/10 || got > base*12/10 {
+			t.Errorf("attempt %d: expected backoff within 20%% of %v, got %v", attempt, base, got)
+		}
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := map[string]struct {
+		output []string
+		err    error
+		want   ErrorClass
+	}{
+		"no output":            {nil, nil, ErrorClassUnknown},
+		"unclassified output":  {[]string{"Error: something went wrong"}, nil, ErrorClassUnknown},
+		"nonce in output":      {[]string{"Error: invalid transaction nonce"}, nil, ErrorClassNonceMismatch},
+		"consensus in output":  {[]string{"Submitted", "consensus not reached"}, nil, ErrorClassConsensus},
+		"timeout in error":     {nil, errors.New("context deadline exceeded"), ErrorClassTimeout},
+		"network in error":     {[]string{"Error:"}, errors.New("dial tcp: connection refused"), ErrorClassNetwork},
+		"insufficient balance": {[]string{"Error: insufficient balance to lock"}, nil, ErrorClassInsufficientBalance},
+		"auth failure":         {[]string{"Error: only owner can update"}, nil, ErrorClassAuth},
+		"http auth failure":    {nil, errors.New("request failed with status code: 403 Forbidden"), ErrorClassAuth},
+		"local permissions":    {[]string{"open ./config/wallet.json: permission denied"}, nil, ErrorClassUnknown},
+		"first pattern wins":   {[]string{"insufficient balance", "consensus not reached"}, nil, ErrorClassInsufficientBalance},
+		"case is ignored":      {[]string{"Request TIMED OUT"}, nil, ErrorClassTimeout},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := ClassifyError(tt.output, tt.err); got != tt.want {
+				t.Errorf("expected class %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestDefaultRetryPolicyRetriesTransientErrorsOnly(t *testing.T) {
+	policy := DefaultRetryPolicy(3, time.Second)
+	for class, want := range map[ErrorClass]bool{
+		ErrorClassNonceMismatch:       true,
+		ErrorClassConsensus:           true,
+		ErrorClassTimeout:             true,
+		ErrorClassNetwork:             true,
+		ErrorClassInsufficientBalance: false,
+		ErrorClassAuth:                false,
+		ErrorClassUnknown:             false,
+	} {
+		if got := policy.ShouldRetry(1, class); got != want {
+			t.Errorf("expected retrying [%s] errors to be %v, got %v", class, want, got)
+		}
+	}
+	if policy.ShouldRetry(3, ErrorClassConsensus) {
+		t.Error("expected no retry after the last attempt")
+	}
+}
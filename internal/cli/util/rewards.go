@@ -0,0 +1,363 @@
+package cliutils
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+
+	"github.com/0chain/system_test/internal/cli/model"
+)
+
+const sasPerZCN = 1e10
+
+// RewardConfig holds the miner smart contract settings which determine the block and fee rewards of each round
+type RewardConfig struct {
+	// BlockReward is the reward for a block in ZCN, before it declines with each epoch
+	BlockReward       float64
+	Epoch             int64
+	RewardDeclineRate float64
+	// ShareRatio is the share of block rewards and fees paid to the miner, the rest is split between sharders
+	ShareRatio                  float64
+	NumShardersRewarded         int
+	NumMinerDelegatesRewarded   int
+	NumSharderDelegatesRewarded int
+}
+
+// NewRewardConfig reads the reward settings from the miner smart contract config, as listed by zwallet mn-config
+func NewRewardConfig(minerScConfig map[string]float64) RewardConfig {
+	return RewardConfig{
+		BlockReward:                 minerScConfig["block_reward"],
+		Epoch:                       int64(minerScConfig["epoch"]),
+		RewardDeclineRate:           minerScConfig["reward_decline_rate"],
+		ShareRatio:                  minerScConfig["share_ratio"],
+		NumShardersRewarded:         int(minerScConfig["num_sharders_rewarded"]),
+		NumMinerDelegatesRewarded:   int(minerScConfig["num_miner_delegates_rewarded"]),
+		NumSharderDelegatesRewarded: int(minerScConfig["num_sharder_delegates_rewarded"]),
+	}
+}
+
+// BlockRewards returns the block reward of the round paid to the miner, and to all sharders together
+func (c RewardConfig) BlockRewards(round int64) (minerReward, sharderReward int64) {
+	var epoch int64
+	if c.Epoch > 0 {
+		epoch = round / c.Epoch
+	}
+	declineRate := math.Pow(1.0-c.RewardDeclineRate, float64(epoch))
+	blockReward := c.BlockReward * sasPerZCN * declineRate
+	minerReward = int64(blockReward * c.ShareRatio)
+	sharderReward = int64(blockReward) - minerReward
+	return minerReward, sharderReward
+}
+
+// RewardDiff is a reward payment which does not match the payment expected in its round
+type RewardDiff struct {
+	Round      int64
+	RewardType model.Reward
+	ProviderID string
+	// PoolID is set for delegate rewards
+	PoolID   string
+	Expected int64
+	Actual   int64
+	// Reason explains diffs which are not about an amount, e.g. the wrong number of delegates rewarded
+	Reason string
+}
+
+func (d RewardDiff) String() string {
+	recipient := "provider " + d.ProviderID
+	switch {
+	case d.ProviderID == "":
+		recipient = "providers"
+	case d.PoolID != "":
+		recipient += " pool " + d.PoolID
+	}
+	if d.Reason != "" {
+		return fmt.Sprintf("%s %s: %s", d.RewardType, recipient, d.Reason)
+	}
+	return fmt.Sprintf("%s %s: expected %d, actual %d", d.RewardType, recipient, d.Expected, d.Actual)
+}
+
+// RewardReport lists the reward diffs of rounds From to To
+type RewardReport struct {
+	From, To int64
+	Diffs    []RewardDiff
+}
+
+// Reconciled reports whether all rewards were paid as expected
+func (r *RewardReport) Reconciled() bool {
+	return len(r.Diffs) == 0
+}
+
+// String lists the diffs grouped by round
+func (r *RewardReport) String() string {
+	if r.Reconciled() {
+		return fmt.Sprintf("rewards of rounds %d to %d reconciled", r.From, r.To)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d reward diffs in rounds %d to %d", len(r.Diffs), r.From, r.To)
+	round := int64(-1)
+	for _, d := range r.Diffs {
+		if d.Round != round {
+			round = d.Round
+			fmt.Fprintf(&b, "\nround %d:", round)
+		}
+		fmt.Fprintf(&b, "\n  %s", d)
+	}
+	return b.String()
+}
+
+// RewardReconciler computes the provider and delegate rewards expected each round from the miner smart contract
+// config and a snapshot of the miners and sharders, and compares them with the rewards recorded in a ChainHistory.
+type RewardReconciler struct {
+	Config RewardConfig
+	// Delta is the rounding error tolerated for each payment, in SAS
+	Delta    int64
+	miners   map[string]model.Node
+	sharders map[string]model.Node
+}
+
+// NewRewardReconciler uses the service charges and delegate pool balances of the given miners and sharders,
+// usually read at the start of the rounds to reconcile.
+func NewRewardReconciler(config RewardConfig, miners, sharders []model.Node) *RewardReconciler {
+	r := &RewardReconciler{
+		Config:   config,
+		Delta:    1,
+		miners:   make(map[string]model.Node, len(miners)),
+		sharders: make(map[string]model.Node, len(sharders)),
+	}
+	for _, miner := range miners {
+		r.miners[miner.ID] = miner
+	}
+	for _, sharder := range sharders {
+		r.sharders[sharder.ID] = sharder
+	}
+	return r
+}
+
+// Reconcile compares the expected and actual block rewards of rounds from to to, and the fee rewards too
+// if the history was read with transactions. Rewards of miners or sharders are skipped if none were given.
+func (r *RewardReconciler) Reconcile(t *test.SystemTest, history *ChainHistory, from, to int64) *RewardReport {
+	report := &RewardReport{From: from, To: to}
+	for round := from; round <= to; round++ {
+		r.reconcileRound(report, round, history.RoundHistory(t, round), history.hasTransactions)
+	}
+	return report
+}
+
+// reconcileRound adds the reward diffs of the round to the report, including fee rewards if withFees is set
+func (r *RewardReconciler) reconcileRound(report *RewardReport, round int64, roundHistory RoundHistory, withFees bool) {
+	numSharders := r.Config.NumShardersRewarded
+	if numSharders > len(r.sharders) {
+		numSharders = len(r.sharders)
+	}
+	winner := roundHistory.Block.MinerID
+	minerReward, sharderReward := r.Config.BlockRewards(round)
+
+	var fees int64
+	for _, txn := range roundHistory.Transactions {
+		fees += txn.Fee
+	}
+
+	if len(r.miners) > 0 {
+		r.reconcileRewards(report, round, roundHistory, model.BlockRewardMiner, r.miners, minerReward, winner, 1, r.Config.NumMinerDelegatesRewarded)
+		if withFees {
+			minerFees := int64(float64(fees) * r.Config.ShareRatio)
+			r.reconcileRewards(report, round, roundHistory, model.FeeRewardMiner, r.miners, minerFees, winner, 1, r.Config.NumMinerDelegatesRewarded)
+		}
+	}
+	if len(r.sharders) > 0 {
+		r.reconcileRewards(report, round, roundHistory, model.BlockRewardSharder, r.sharders, sharderReward, "", numSharders, r.Config.NumSharderDelegatesRewarded)
+		if withFees {
+			sharderFees := int64(float64(fees) * (1 - r.Config.ShareRatio))
+			r.reconcileRewards(report, round, roundHistory, model.FeeRewardSharder, r.sharders, sharderFees, "", numSharders, r.Config.NumSharderDelegatesRewarded)
+		}
+	}
+}
+
+// reconcileRewards splits the total evenly between numPaid providers, which are the winner if given or any numPaid
+// of the nodes otherwise. Each provider is paid once, keeps its service charge and shares the rest between its delegates.
+func (r *RewardReconciler) reconcileRewards(
+	report *RewardReport,
+	round int64,
+	roundHistory RoundHistory,
+	rewardType model.Reward,
+	nodes map[string]model.Node,
+	total int64,
+	winner string,
+	numPaid, numDelegates int,
+) {
+	paid := make(map[string]int64)
+	payments := make(map[string]int)
+	for _, pr := range roundHistory.ProviderRewards {
+		if pr.RewardType == rewardType {
+			paid[pr.ProviderId] += pr.Amount
+			payments[pr.ProviderId]++
+		}
+	}
+	delegatesPaid := make(map[string]map[string]int64)
+	delegatePayments := make(map[string]map[string]int)
+	for _, dr := range roundHistory.DelegateRewards {
+		if dr.RewardType != rewardType {
+			continue
+		}
+		if delegatesPaid[dr.ProviderID] == nil {
+			delegatesPaid[dr.ProviderID] = make(map[string]int64)
+			delegatePayments[dr.ProviderID] = make(map[string]int)
+		}
+		delegatesPaid[dr.ProviderID][dr.PoolID] += dr.Amount
+		delegatePayments[dr.ProviderID][dr.PoolID]++
+	}
+
+	diff := func(providerID, poolID string, expected, actual int64, reason string) {
+		report.Diffs = append(report.Diffs, RewardDiff{
+			Round:      round,
+			RewardType: rewardType,
+			ProviderID: providerID,
+			PoolID:     poolID,
+			Expected:   expected,
+			Actual:     actual,
+			Reason:     reason,
+		})
+	}
+
+	var perProvider int64
+	if total > 0 && numPaid > 0 {
+		perProvider = int64(float64(total) / float64(numPaid))
+	}
+	expected := make(map[string]bool)
+	switch {
+	case perProvider == 0:
+	case winner != "":
+		expected[winner] = true
+	default:
+		// any numPaid of the nodes may be chosen, so the rewarded nodes are expected as long as there are numPaid
+		// of them. A provider with a service charge of zero is only rewarded through its delegates.
+		for id := range paid {
+			if _, ok := nodes[id]; ok {
+				expected[id] = true
+			}
+		}
+		for id := range delegatesPaid {
+			if _, ok := nodes[id]; ok {
+				expected[id] = true
+			}
+		}
+		if known := len(expected); known != numPaid {
+			diff("", "", int64(numPaid), int64(known),
+				fmt.Sprintf("expected %d providers rewarded, %d were", numPaid, known))
+		}
+	}
+
+	for _, id := range sortedKeys(payments) {
+		if payments[id] > 1 {
+			diff(id, "", 1, int64(payments[id]), fmt.Sprintf("rewarded %d times, expected once", payments[id]))
+		}
+	}
+	for _, id := range sortedKeys(delegatePayments) {
+		for _, poolID := range sortedKeys(delegatePayments[id]) {
+			if n := delegatePayments[id][poolID]; n > 1 {
+				diff(id, poolID, 1, int64(n), fmt.Sprintf("rewarded %d times, expected once", n))
+			}
+		}
+	}
+
+	for _, id := range sortedKeys(paid) {
+		if !expected[id] {
+			diff(id, "", 0, paid[id], "")
+		}
+	}
+	for _, id := range sortedKeys(delegatesPaid) {
+		if expected[id] {
+			continue
+		}
+		for _, poolID := range sortedKeys(delegatesPaid[id]) {
+			diff(id, poolID, 0, delegatesPaid[id][poolID], "")
+		}
+	}
+
+	for _, id := range sortedKeys(expected) {
+		node, ok := nodes[id]
+		if !ok {
+			diff(id, "", perProvider, paid[id], "not a known provider")
+			continue
+		}
+
+		serviceCharge, delegateReward := perProvider, int64(0)
+		if len(node.Pools) > 0 && numDelegates > 0 {
+			serviceCharge = int64(float64(perProvider) * node.Settings.ServiceCharge)
+			delegateReward = int64(float64(perProvider) * (1 - node.Settings.ServiceCharge))
+		}
+		if !r.withinDelta(serviceCharge, paid[id]) {
+			diff(id, "", serviceCharge, paid[id], "")
+		}
+		r.reconcileDelegates(node, delegateReward, numDelegates, delegatesPaid[id], diff)
+	}
+}
+
+// reconcileDelegates checks the reward is shared between min(numDelegates, pools) delegate pools of the provider,
+// in proportion to their stake.
+func (r *RewardReconciler) reconcileDelegates(
+	node model.Node,
+	total int64,
+	numDelegates int,
+	paid map[string]int64,
+	diff func(providerID, poolID string, expected, actual int64, reason string),
+) {
+	want := 0
+	if total > 0 {
+		want = numDelegates
+		if want > len(node.Pools) {
+			want = len(node.Pools)
+		}
+	}
+	if len(paid) != want {
+		diff(node.ID, "", int64(want), int64(len(paid)),
+			fmt.Sprintf("expected %d delegate pools rewarded, %d were", want, len(paid)))
+	}
+
+	var stake float64
+	for id := range paid {
+		if pool, ok := node.Pools[id]; ok {
+			stake += float64(pool.Balance)
+		}
+	}
+	for _, id := range sortedKeys(paid) {
+		pool, ok := node.Pools[id]
+		if !ok {
+			diff(node.ID, id, 0, paid[id], "not a delegate pool of the provider")
+			continue
+		}
+
+		var expected int64
+		switch {
+		case want == 0:
+		case stake > 0:
+			expected = int64(float64(pool.Balance) / stake * float64(total))
+		default:
+			expected = int64(float64(total) / float64(len(paid)))
+		}
+		if !r.withinDelta(expected, paid[id]) {
+			diff(node.ID, id, expected, paid[id], "")
+		}
+	}
+}
+
+func (r *RewardReconciler) withinDelta(expected, actual int64) bool {
+	d := expected - actual
+	if d < 0 {
+		d = -d
+	}
+	return d <= r.Delta
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
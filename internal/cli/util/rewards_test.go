@@ -0,0 +1,165 @@
+package cliutils
+
+import (
+	"testing"
+
+	"github.com/0chain/system_test/internal/cli/model"
+	"github.com/stretchr/testify/require"
+)
+
+func rewardNode(id, poolID string) model.Node {
+	return model.Node{
+		SimpleNode: model.SimpleNode{ID: id},
+		StakePool: model.StakePool{
+			Pools:    map[string]*model.DelegatePool{poolID: {Balance: 100}},
+			Settings: model.StakePoolSettings{ServiceCharge: 0.1},
+		},
+	}
+}
+
+func providerReward(rewardType model.Reward, providerID string, amount int64) model.RewardProvider {
+	return model.RewardProvider{RewardType: rewardType, ProviderId: providerID, Amount: amount}
+}
+
+func delegateReward(rewardType model.Reward, providerID, poolID string, amount int64) model.RewardDelegate {
+	return model.RewardDelegate{RewardType: rewardType, ProviderID: providerID, PoolID: poolID, Amount: amount}
+}
+
+func TestRewardReconcilerRound(t *testing.T) {
+	// a block reward of 1 ZCN is split into 8e9 SAS for the miner and 1e9 SAS for each of 2 sharders,
+	// of which each provider keeps a service charge of 10%
+	config := RewardConfig{
+		BlockReward:                 1,
+		Epoch:                       1000000,
+		ShareRatio:                  0.8,
+		NumShardersRewarded:         2,
+		NumMinerDelegatesRewarded:   1,
+		NumSharderDelegatesRewarded: 1,
+	}
+	miners := []model.Node{rewardNode("m1", "m1-pool"), rewardNode("m2", "m2-pool")}
+	sharders := []model.Node{rewardNode("s1", "s1-pool"), rewardNode("s2", "s2-pool"), rewardNode("s3", "s3-pool")}
+
+	minerRewards := func(minerID string) ([]model.RewardProvider, []model.RewardDelegate) {
+		return []model.RewardProvider{providerReward(model.BlockRewardMiner, minerID, 8e8)},
+			[]model.RewardDelegate{delegateReward(model.BlockRewardMiner, minerID, minerID+"-pool", 72e8)}
+	}
+	sharderRewards := func(sharderIDs ...string) ([]model.RewardProvider, []model.RewardDelegate) {
+		var providers []model.RewardProvider
+		var delegates []model.RewardDelegate
+		for _, id := range sharderIDs {
+			providers = append(providers, providerReward(model.BlockRewardSharder, id, 1e8))
+			delegates = append(delegates, delegateReward(model.BlockRewardSharder, id, id+"-pool", 9e8))
+		}
+		return providers, delegates
+	}
+	history := func(rewards ...func() ([]model.RewardProvider, []model.RewardDelegate)) RoundHistory {
+		h := RoundHistory{Block: &model.EventDBBlock{MinerID: "m1"}}
+		for _, reward := range rewards {
+			providers, delegates := reward()
+			h.ProviderRewards = append(h.ProviderRewards, providers...)
+			h.DelegateRewards = append(h.DelegateRewards, delegates...)
+		}
+		return h
+	}
+	miner := func(id string) func() ([]model.RewardProvider, []model.RewardDelegate) {
+		return func() ([]model.RewardProvider, []model.RewardDelegate) { return minerRewards(id) }
+	}
+	sharder := func(ids ...string) func() ([]model.RewardProvider, []model.RewardDelegate) {
+		return func() ([]model.RewardProvider, []model.RewardDelegate) { return sharderRewards(ids...) }
+	}
+
+	tests := map[string]struct {
+		history RoundHistory
+		want    []string
+	}{
+		"reconciled": {
+			history: history(miner("m1"), sharder("s1", "s2")),
+		},
+		"any sharders may be rewarded": {
+			history: history(miner("m1"), sharder("s2", "s3")),
+		},
+		"block reward paid to a miner other than the winner": {
+			history: history(miner("m2"), sharder("s1", "s2")),
+			want: []string{
+				"block_reward_miner provider m2: expected 0, actual 800000000",
+				"block_reward_miner provider m2 pool m2-pool: expected 0, actual 7200000000",
+				"block_reward_miner provider m1: expected 800000000, actual 0",
+				"block_reward_miner provider m1: expected 1 delegate pools rewarded, 0 were",
+			},
+		},
+		"too few sharders rewarded": {
+			history: history(miner("m1"), sharder("s1")),
+			want:    []string{"block_reward_sharder providers: expected 2 providers rewarded, 1 were"},
+		},
+		"unknown sharder rewarded": {
+			history: history(miner("m1"), sharder("s1", "unknown")),
+			want: []string{
+				"block_reward_sharder providers: expected 2 providers rewarded, 1 were",
+				"block_reward_sharder provider unknown: expected 0, actual 100000000",
+				"block_reward_sharder provider unknown pool unknown-pool: expected 0, actual 900000000",
+			},
+		},
+		"sharder rewarded twice": {
+			history: history(miner("m1"), sharder("s1", "s2", "s2")),
+			want: []string{
+				"block_reward_sharder provider s2: rewarded 2 times, expected once",
+				"block_reward_sharder provider s2 pool s2-pool: rewarded 2 times, expected once",
+				"block_reward_sharder provider s2: expected 100000000, actual 200000000",
+				"block_reward_sharder provider s2 pool s2-pool: expected 900000000, actual 1800000000",
+			},
+		},
+		"sharder rewarded only through its delegates": {
+			history: func() RoundHistory {
+				h := history(miner("m1"), sharder("s1", "s2"))
+				h.ProviderRewards = h.ProviderRewards[:2]
+				return h
+			}(),
+			want: []string{"block_reward_sharder provider s2: expected 100000000, actual 0"},
+		},
+		"wrong delegate reward": {
+			history: func() RoundHistory {
+				h := history(miner("m1"), sharder("s1", "s2"))
+				h.DelegateRewards[0].Amount = 7e9
+				return h
+			}(),
+			want: []string{"block_reward_miner provider m1 pool m1-pool: expected 7200000000, actual 7000000000"},
+		},
+	}
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			report := &RewardReport{From: 1, To: 1}
+			NewRewardReconciler(config, miners, sharders).reconcileRound(report, 1, tt.history, false)
+
+			var got []string
+			for _, d := range report.Diffs {
+				got = append(got, d.String())
+			}
+			require.Equal(t, tt.want, got)
+			require.Equal(t, len(tt.want) == 0, report.Reconciled())
+		})
+	}
+}
+
+func TestRewardReconcilerFees(t *testing.T) {
+	config := RewardConfig{ShareRatio: 0.5, NumShardersRewarded: 1}
+	miners := []model.Node{{SimpleNode: model.SimpleNode{ID: "m1"}}}
+	sharders := []model.Node{{SimpleNode: model.SimpleNode{ID: "s1"}}}
+	roundHistory := RoundHistory{
+		Block:        &model.EventDBBlock{MinerID: "m1"},
+		Transactions: []model.EventDBTransaction{{Fee: 600}, {Fee: 400}},
+		ProviderRewards: []model.RewardProvider{
+			providerReward(model.FeeRewardMiner, "m1", 500),
+			providerReward(model.FeeRewardSharder, "s1", 400),
+		},
+	}
+
+	report := &RewardReport{From: 1, To: 1}
+	NewRewardReconciler(config, miners, sharders).reconcileRound(report, 1, roundHistory, true)
+	require.Len(t, report.Diffs, 1, report.String())
+	require.Equal(t, "fees sharder provider s1: expected 500, actual 400", report.Diffs[0].String())
+
+	report = &RewardReport{From: 1, To: 1}
+	NewRewardReconciler(config, miners, sharders).reconcileRound(report, 1, roundHistory, false)
+	require.True(t, report.Reconciled(), "fee rewards must only be reconciled with transactions")
+}
@@ -1,16 +1,20 @@
 package cliutils
 
 import (
-	"crypto/rand"
+	"bytes"
+	"context"
 	"fmt"
-	"math/big"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/0chain/system_test/internal/api/util/cassette"
+	"github.com/0chain/system_test/internal/api/util/redact"
 	"github.com/0chain/system_test/internal/api/util/test"
 
 	"github.com/0chain/system_test/internal/cli/util/specific"
@@ -20,17 +24,29 @@ import (
 
 var Logger = getLogger()
 
+// RunCommandWithoutRetry runs the command once. It has no test case, so it is not recorded to cassettes;
+// tests use RunCommandWithoutRetryContext with t.Context() instead.
 func RunCommandWithoutRetry(commandString string) ([]string, error) {
-	command := parseCommand(commandString)
-	commandName := command[0]
-	args := command[1:]
+	return RunCommandWithoutRetryContext(context.Background(), commandString)
+}
 
-	sanitizedArgs := sanitizeArgs(args)
-	rawOutput, err := executeCommand(commandName, sanitizedArgs)
+// RunCommandWithoutRetryContext runs the command once, killing its whole process group if ctx is done before it exits.
+// It is recorded to the cassette of the test case carried by ctx.
+func RunCommandWithoutRetryContext(ctx context.Context, commandString string) ([]string, error) {
+	return runArgv(ctx, commandString, sanitizeArgs(parseCommand(commandString)))
+}
+
+func runArgv(ctx context.Context, commandString string, argv []string) ([]string, error) {
+	result, err := executeArgv(ctx, commandString, argv)
+	return result.Sanitized(), err
+}
 
-	Logger.Debugf("Command [%v] exited with error [%v] and output [%v]", commandString, err, sanitizeOutput(rawOutput))
+func executeArgv(ctx context.Context, commandString string, argv []string) (*CommandResult, error) {
+	result, err := executeWithCassette(ctx, commandString, argv)
 
-	return sanitizeOutput(rawOutput), err
+	Logger.Debugf("Command [%v] exited with code [%v], error [%v] and output [%v]", commandString, result.ExitCode, err, result.Sanitized())
+
+	return result, err
 }
 
 func RunCommandWithRawOutput(commandString string) ([]string, error) {
@@ -39,51 +55,96 @@ func RunCommandWithRawOutput(commandString string) ([]string, error) {
 	args := command[1:]
 
 	sanitizedArgs := sanitizeArgs(args)
-	rawOutput, err := executeCommand(commandName, sanitizedArgs)
+	result, err := executeCommand(context.Background(), commandString, commandName, sanitizedArgs)
 
-	Logger.Debugf("Command [%v] exited with error [%v] and output [%v]", commandString, err, string(rawOutput))
+	Logger.Debugf("Command [%v] exited with error [%v] and output [%v]", commandString, err, string(result.Combined))
 
-	output := strings.Split(string(rawOutput), "\n")
+	output := strings.Split(string(result.Combined), "\n")
 
 	return output, err
 }
 
+// RunCommand runs the command, retrying transient failures up to maxAttempts times with an exponential backoff
+// starting at the given backoff. The policy can be overridden for a test with SetRetryPolicy.
 func RunCommand(t *test.SystemTest, commandString string, maxAttempts int, backoff time.Duration) ([]string, error) {
+	return RunCommandWithPolicy(t, commandString, retryPolicyFor(t, maxAttempts, backoff))
+}
+
+func RunCommandWithPolicy(t *test.SystemTest, commandString string, policy RetryPolicy) ([]string, error) {
+	result, err := executeWithRetry(t, commandString, sanitizeArgs(parseCommand(commandString)), policy)
+	return result.Sanitized(), err
+}
+
+func executeWithRetry(t *test.SystemTest, commandString string, argv []string, policy RetryPolicy) (*CommandResult, error) {
 	red := "\033[31m"
 	yellow := "\033[33m"
 	green := "\033[32m"
 
+	ctx := t.Context()
+	maxAttempts := policy.MaxAttempts
+
 	var count int
 	for {
 		count++
-		output, err := RunCommandWithoutRetry(commandString)
+		result, err := executeWithCassette(ctx, commandString, argv)
+		output := result.Sanitized()
+		t.RecordCommand(commandString, strings.Join(output, "\n"), err)
 
 		if err == nil {
 			if count > 1 {
 				t.Logf("%sCommand passed on retry [%v/%v]. Output: [%v]\n", green, count, maxAttempts, strings.Join(output, " -<NEWLINE>- "))
 			}
-			return output, nil
-		} else if count < maxAttempts {
-			t.Logf("%sCommand failed on attempt [%v/%v] due to error [%v]. Output: [%v]\n", yellow, count, maxAttempts, err, strings.Join(output, " -<NEWLINE>- "))
-			time.Sleep(backoff)
-		} else {
-			t.Logf("%sCommand failed on final attempt [%v/%v] due to error [%v]. Command String: [%v] Output: [%v]\n", red, count, maxAttempts, err, commandString, strings.Join(output, " -<NEWLINE>- "))
-
-			if err != nil {
-				t.Logf("%sThe verbose output for the command is:", red)
-				commandString = strings.Replace(commandString, "--silent", "", 1)
-				out, _ := RunCommandWithoutRetry(commandString) // Only for logging!
-				for _, line := range out {
-					t.Logf("%s%s", red, line)
-				}
+			return result, nil
+		}
+
+		class := ClassifyError(output, err)
+		if ctx.Err() == nil && policy.ShouldRetry(count, class) {
+			backoff := policy.Backoff(count)
+			t.Logf("%sCommand failed on attempt [%v/%v] due to [%v] error [%v], retrying in [%v]. Output: [%v]\n", yellow, count, maxAttempts, class, err, backoff.Round(time.Millisecond), strings.Join(output, " -<NEWLINE>- "))
+			if !cassette.Replaying() {
+				sleep(ctx, backoff)
 			}
+			t.RecordCommandRetry()
+			continue
+		}
 
-			return output, err
+		if count < maxAttempts && ctx.Err() == nil {
+			t.Logf("%sNot retrying command as [%v] errors are not transient", red, class)
 		}
+		t.Logf("%sCommand failed on final attempt [%v/%v] due to [%v] error [%v]. Command String: [%v] Output: [%v]\n", red, count, maxAttempts, class, err, commandString, strings.Join(output, " -<NEWLINE>- "))
+
+		if ctx.Err() == nil && !cassette.Replaying() {
+			t.Logf("%sThe verbose output for the command is:", red)
+			// Only for logging, so it is not recorded
+			verbose := withoutArg(argv, "--silent")
+			out, _ := executeCommand(ctx, commandString, verbose[0], verbose[1:])
+			for _, line := range out.Sanitized() {
+				t.Logf("%s%s", red, line)
+			}
+		}
+
+		return result, err
 	}
 }
 
+// withoutArg returns a copy of argv without the first occurrence of arg
+func withoutArg(argv []string, arg string) []string {
+	if i, ok := Contains(argv, arg); ok {
+		return append(append([]string(nil), argv[:i]...), argv[i+1:]...)
+	}
+	return argv
+}
+
+// StartCommand starts the command, retrying failed starts. The output of the command is not captured, so it cannot be
+// recorded to or replayed from a cassette; StartProcess supervises a command whose output is recorded.
 func StartCommand(t *test.SystemTest, commandString string, maxAttempts int, backoff time.Duration) (cmd *exec.Cmd, err error) {
+	if cassette.ForContext(t.Context()) != nil {
+		if cassette.Replaying() {
+			return nil, fmt.Errorf("command [%s] started by StartCommand cannot be replayed, use StartProcess", commandString)
+		}
+		t.Logf("Command [%s] started by StartCommand is not recorded, use StartProcess", commandString)
+	}
+
 	var count int
 	for {
 		count++
@@ -93,15 +154,17 @@ func StartCommand(t *test.SystemTest, commandString string, maxAttempts int, bac
 			if count > 1 {
 				t.Logf("Command started on retry [%v/%v].", count, maxAttempts)
 			}
+			killOnDone(t.Context(), cmd)
 			return cmd, err
-		} else if count < maxAttempts {
+		} else if count < maxAttempts && t.Context().Err() == nil {
 			t.Logf("Command failed on attempt [%v/%v] due to error [%v]\n", count, maxAttempts, err)
 			t.Logf("Sleeping for backoff duration: %v\n", backoff)
-			_ = cmd.Process.Kill()
-			time.Sleep(backoff)
+			_ = specific.KillProcessGroup(cmd)
+			sleep(t.Context(), backoff)
+			t.RecordCommandRetry()
 		} else {
 			t.Logf("Command failed on final attempt [%v/%v] due to error [%v].\n", count, maxAttempts, err)
-			_ = cmd.Process.Kill()
+			_ = specific.KillProcessGroup(cmd)
 			return cmd, err
 		}
 	}
@@ -120,15 +183,12 @@ func StartCommandWithoutRetry(commandString string) (cmd *exec.Cmd, err error) {
 	return cmd, err
 }
 
-func RandomAlphaNumericString(n int) string {
+// RandomAlphaNumericString returns a random string of length n drawn from the random source of the test case.
+func RandomAlphaNumericString(t *test.SystemTest, n int) string {
 	const letters = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-"
 	ret := make([]byte, n)
 	for i := 0; i < n; i++ {
-		num, err := rand.Int(rand.Reader, big.NewInt(int64(len(letters))))
-		if err != nil {
-			return ""
-		}
-		ret[i] = letters[num.Int64()]
+		ret[i] = letters[t.Rand().Intn(len(letters))]
 	}
 
 	return string(ret)
@@ -136,7 +196,27 @@ func RandomAlphaNumericString(n int) string {
 
 func Wait(t *test.SystemTest, duration time.Duration) {
 	t.Logf("Waiting %s...", duration)
-	time.Sleep(duration)
+	sleep(t.Context(), duration)
+}
+
+// sleep waits for duration, returning early if ctx is done.
+func sleep(ctx context.Context, duration time.Duration) {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+	case <-timer.C:
+	}
+}
+
+// killOnDone kills the process group of a started command once ctx is done, so no child
+// process outlives the test case which started it.
+func killOnDone(ctx context.Context, cmd *exec.Cmd) {
+	go func() {
+		<-ctx.Done()
+		_ = specific.KillProcessGroup(cmd)
+	}()
 }
 
 func sanitizeOutput(rawOutput []byte) []string {
@@ -169,11 +249,65 @@ func unique(slice []string) []string {
 	return uniqueOutput
 }
 
-func executeCommand(commandName string, args []string) ([]byte, error) {
+func executeCommand(ctx context.Context, commandString, commandName string, args []string) (*CommandResult, error) {
+	result := &CommandResult{Command: commandString, ExitCode: -1}
+	if err := ctx.Err(); err != nil {
+		return result, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	combined := &lockedBuffer{}
 	cmd := exec.Command(commandName, args...)
-	rawOutput, err := cmd.CombinedOutput()
+	cmd.Stdout = io.MultiWriter(&stdout, combined)
+	cmd.Stderr = io.MultiWriter(&stderr, combined)
+	specific.Setpgid(cmd)
+
+	startedAt := time.Now()
+	if err := cmd.Start(); err != nil {
+		return result, err
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			_ = specific.KillProcessGroup(cmd)
+		case <-exited:
+		}
+	}()
+
+	err := cmd.Wait()
+	close(exited)
 
-	return rawOutput, err
+	result.Duration = time.Since(startedAt)
+	result.Stdout = stdout.Bytes()
+	result.Stderr = stderr.Bytes()
+	result.Combined = combined.Bytes()
+	result.ExitCode = exitCode(err)
+
+	if err != nil && ctx.Err() != nil {
+		err = fmt.Errorf("%w: %v", ctx.Err(), err)
+	}
+
+	return result, err
+}
+
+// lockedBuffer is written to by both the stdout and stderr copying goroutines of a command
+type lockedBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) Bytes() []byte {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return b.buffer.Bytes()
 }
 
 func sanitizeArgs(args []string) []string {
@@ -196,9 +330,9 @@ func getLogger() *logrus.Logger {
 	logger := logrus.New()
 	logger.Out = os.Stdout
 
-	logger.SetFormatter(&logrus.TextFormatter{
+	logger.SetFormatter(&redactingFormatter{&logrus.TextFormatter{
 		DisableQuote: true,
-	})
+	}})
 
 	if strings.EqualFold(strings.TrimSpace(os.Getenv("DEBUG")), "true") {
 		logger.SetLevel(logrus.DebugLevel)
@@ -207,6 +341,19 @@ func getLogger() *logrus.Logger {
 	return logger
 }
 
+// redactingFormatter masks secrets in log entries formatted by the wrapped formatter
+type redactingFormatter struct {
+	logrus.Formatter
+}
+
+func (f *redactingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	out, err := f.Formatter.Format(entry)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(redact.String(string(out))), nil
+}
+
 func Contains(slice []string, val string) (int, bool) {
 	for i, item := range slice {
 		if item == val {
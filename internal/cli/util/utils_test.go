@@ -0,0 +1,63 @@
+package cliutils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/cli/fake/fakecli"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeOutput(t *testing.T) {
+	tests := map[string]struct {
+		raw  string
+		want []string
+	}{
+		"empty":                        {"", nil},
+		"blank lines are dropped":      {"\n  first \n\n\tsecond\n \n", []string{"first", "second"}},
+		"progress lines are collapsed": {"10%\r20%\r20%\rdone\n", []string{"10% 20% done"}},
+		"repeated lines are kept once": {"line\nline\nother\nline\n", []string{"line", "other"}},
+		"windows line endings":         {"first\r\nsecond\r\n", []string{"first", "second"}},
+		"repeated progress is dropped": {"50%\r50%\n50%\n", []string{"50%"}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tt.want, sanitizeOutput([]byte(tt.raw)))
+		})
+	}
+}
+
+func TestRunCommandRetry(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+	fakecli.RegisterWallet(t, "retry_wallet.json", 1)
+	getNonce := "./zwallet getnonce --silent --wallet retry_wallet.json --configDir ./config --config " + fakecli.ConfigFile
+	getWallet := "./zbox getwallet --json --silent --wallet retry_wallet.json --configDir ./config --config " + fakecli.ConfigFile
+	getBalance := "./zwallet getbalance --silent --wallet retry_wallet.json --configDir ./config --config " + fakecli.ConfigFile
+
+	t.RunSequentially("Transient failures are retried", func(t *test.SystemTest) {
+		t.Setenv("FAKE_CLI_FAILURES", "getnonce:2:consensus not reached")
+
+		output, err := RunCommand(t, getNonce, 3, time.Millisecond)
+		require.NoError(t, err, strings.Join(output, "\n"))
+		require.Equal(t, []string{"Nonce: 0"}, output)
+	})
+
+	t.RunSequentially("Deterministic failures are not retried", func(t *test.SystemTest) {
+		t.Setenv("FAKE_CLI_FAILURES", "getbalance:1:insufficient balance")
+
+		output, err := RunCommand(t, getBalance, 3, time.Millisecond)
+		require.Error(t, err)
+		require.Equal(t, ErrorClassInsufficientBalance, ClassifyError(output, err))
+	})
+
+	t.RunSequentially("Unclassified failures are not retried", func(t *test.SystemTest) {
+		t.Setenv("FAKE_CLI_FAILURES", "getwallet:1:something went wrong")
+
+		output, err := RunCommand(t, getWallet, 3, time.Millisecond)
+		require.Error(t, err)
+		require.Equal(t, ErrorClassUnknown, ClassifyError(output, err))
+	})
+}
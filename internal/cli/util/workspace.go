@@ -0,0 +1,107 @@
+package cliutils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+)
+
+// Workspace is a config dir of its own for a test case, so its wallet and allocation files
+// cannot collide with those of parallel test cases. It is removed once the test case finishes.
+type Workspace struct {
+	// Dir is the absolute path of the config dir, passed to zbox and zwallet as --configDir
+	Dir string
+	// Config, Wallet and AllocationFile are file names relative to Dir
+	Config         string
+	Wallet         string
+	AllocationFile string
+}
+
+// Workspace file names
+const (
+	WorkspaceConfig         = "zbox_config.yaml"
+	WorkspaceWallet         = "wallet.json"
+	WorkspaceAllocationFile = "allocation.txt"
+)
+
+// NewWorkspace creates a workspace holding a copy of the config file, relative to sharedConfigDir unless absolute,
+// and read-only copies of the shared wallets, e.g. owner wallets, which keep their path relative to sharedConfigDir.
+func NewWorkspace(t *test.SystemTest, sharedConfigDir, configFile string, sharedWallets ...string) *Workspace {
+	w := &Workspace{
+		Dir:            t.TempDir(),
+		Config:         WorkspaceConfig,
+		Wallet:         WorkspaceWallet,
+		AllocationFile: WorkspaceAllocationFile,
+	}
+
+	if !filepath.IsAbs(configFile) {
+		configFile = filepath.Join(sharedConfigDir, configFile)
+	}
+	config, err := os.ReadFile(configFile)
+	if err != nil {
+		t.Fatalf("Failed to read config file for workspace due to error: %v", err)
+	}
+	if err := os.WriteFile(w.Path(w.Config), config, 0644); err != nil { //nolint:gosec
+		t.Fatalf("Failed to write config file to workspace due to error: %v", err)
+	}
+
+	for _, wallet := range sharedWallets {
+		if err := copyReadOnly(filepath.Join(sharedConfigDir, wallet), w.Path(wallet)); err != nil {
+			t.Fatalf("Failed to copy shared wallet [%v] to workspace due to error: %v", wallet, err)
+		}
+	}
+
+	t.Logf("Using workspace [%v]", w.Dir)
+	return w
+}
+
+// copyReadOnly copies a shared file into the workspace with mode 0444. A copy is used rather than a symlink,
+// as writes through a symlink would change the shared file.
+func copyReadOnly(source, target string) error {
+	content, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil { //nolint:gosec
+		return err
+	}
+	if err := os.WriteFile(target, content, 0444); err != nil { //nolint:gosec
+		return err
+	}
+	// the mode given to WriteFile is subject to the umask
+	return os.Chmod(target, 0444)
+}
+
+// Path returns the absolute path of a file in the workspace
+func (w *Workspace) Path(name string) string {
+	return filepath.Join(w.Dir, name)
+}
+
+// Zbox returns a zbox command using the config and wallet of the workspace
+func (w *Workspace) Zbox(subcommand string) *Command {
+	return w.command("./zbox", subcommand)
+}
+
+// Zwallet returns a zwallet command using the config and wallet of the workspace
+func (w *Workspace) Zwallet(subcommand string) *Command {
+	return w.command("./zwallet", subcommand)
+}
+
+func (w *Workspace) command(binary, subcommand string) *Command {
+	return NewCommand(binary, subcommand).
+		WithConfigDir(w.Dir).
+		WithConfig(w.Config).
+		WithWallet(w.Wallet)
+}
+
+// AllocationID returns the ID of the last allocation created in the workspace, read from its allocation file
+func (w *Workspace) AllocationID() (string, error) {
+	content, err := os.ReadFile(w.Path(w.AllocationFile))
+	if err != nil {
+		return "", fmt.Errorf("no allocation created in workspace [%v]: %w", w.Dir, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
@@ -0,0 +1,57 @@
+package cliutils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/cli/fake/fakecli"
+	climodel "github.com/0chain/system_test/internal/cli/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspace(testSetup *testing.T) {
+	t := test.NewSystemTest(testSetup)
+
+	shared := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(shared, fakecli.ConfigFile), []byte("block_worker: http://localhost/dns\n"), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(shared, "wallets"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(shared, "wallets", "owner_wallet.json"), []byte(`{"client_id":"owner","client_key":"owner_key"}`), 0600))
+
+	workspace := NewWorkspace(t, shared, fakecli.ConfigFile, "wallets/owner_wallet.json")
+
+	info, err := os.Lstat(workspace.Path("wallets/owner_wallet.json"))
+	require.NoError(t, err)
+	require.True(t, info.Mode().IsRegular(), "shared wallets must be copied, as writes through a link change the shared file")
+	if runtime.GOOS != "windows" {
+		require.Equal(t, os.FileMode(0444), info.Mode().Perm())
+	}
+
+	output, err := workspace.Zbox("register").WithSilent().Run(t, 1, time.Millisecond)
+	require.NoError(t, err, strings.Join(output, "\n"))
+	output, err = workspace.Zwallet("faucet").WithFlags(Flags{"methodName": "pour", "tokens": 1, "input": "{}"}).Run(t, 1, time.Millisecond)
+	require.NoError(t, err, strings.Join(output, "\n"))
+	output, err = workspace.Zbox("newallocation").WithFlag("lock", 0.5).Run(t, 1, time.Millisecond)
+	require.NoError(t, err, strings.Join(output, "\n"))
+
+	allocationID, err := workspace.AllocationID()
+	require.NoError(t, err)
+	require.Equal(t, "Allocation created: "+allocationID, output[0])
+
+	output, err = workspace.Zwallet("getbalance").WithSilent().Run(t, 1, time.Millisecond)
+	require.NoError(t, err, strings.Join(output, "\n"))
+	require.Equal(t, []string{"Balance: 500.000 mZCN (0.05 USD)"}, output)
+
+	output, err = workspace.Zbox("getwallet").WithFlag("json", nil).WithSilent().WithWallet("wallets/owner_wallet.json").Run(t, 1, time.Millisecond)
+	require.NoError(t, err, strings.Join(output, "\n"))
+	var owner climodel.Wallet
+	require.NoError(t, json.Unmarshal([]byte(output[len(output)-1]), &owner), strings.Join(output, "\n"))
+	require.Equal(t, "owner", owner.ClientID)
+
+	require.NoFileExists(t, filepath.Join(shared, WorkspaceWallet), "workspace files must not be written to the shared config dir")
+}
@@ -1311,7 +1311,7 @@ func Test0Box(testSetup *testing.T) {
 		// FIXME: there are no delete endpoints so we can't teardown
 		csrfToken := createCsrfToken(t, zboxClient.DefaultPhoneNumber)
 
-		username := cliutils.RandomAlphaNumericString(10)
+		username := cliutils.RandomAlphaNumericString(t, 10)
 
 		usernameResponse, response, err := zboxClient.PutUsername(t, username, firebaseToken.IdToken, csrfToken, zboxClient.DefaultPhoneNumber)
 
@@ -1326,7 +1326,7 @@ func Test0Box(testSetup *testing.T) {
 		// FIXME: there are no delete endpoints so we can't teardown
 		csrfToken := createCsrfToken(t, zboxClient.DefaultPhoneNumber)
 
-		username := cliutils.RandomAlphaNumericString(10)
+		username := cliutils.RandomAlphaNumericString(t, 10)
 		_, _, err := zboxClient.PutUsername(t, username, firebaseToken.IdToken, csrfToken, zboxClient.DefaultPhoneNumber)
 		require.NoError(t, err)
 
@@ -1,8 +1,7 @@
 package api_tests
 
 import (
-	"crypto/rand"
-	"math/big"
+	"strconv"
 	"testing"
 	"time"
 
@@ -80,10 +79,9 @@ func TestAddBlobber(testSetup *testing.T) {
 		allocation := apiClient.GetAllocation(t, allocationID, client.HttpOkStatus)
 		numberOfBlobbersBefore := len(allocation.Blobbers)
 
-		result, err := rand.Int(rand.Reader, big.NewInt(10))
-		require.Nil(t, err)
+		result := strconv.Itoa(t.Rand().Intn(10))
 
-		apiClient.UpdateAllocationBlobbers(t, wallet, result.String(), "", allocationID, client.TxUnsuccessfulStatus)
+		apiClient.UpdateAllocationBlobbers(t, wallet, result, "", allocationID, client.TxUnsuccessfulStatus)
 
 		var numberOfBlobbersAfter int
 
@@ -11,6 +11,7 @@ import (
 
 func TestExecuteFaucet(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagSmoke)
 
 	t.Parallel()
 
@@ -50,5 +50,5 @@ func TestMain(m *testing.M) {
 	sdkWallet = apiClient.RegisterWalletForMnemonic(t, sdkWalletMnemonics)
 	sdkClient.SetWallet(t, sdkWallet, sdkWalletMnemonics)
 
-	os.Exit(m.Run())
+	os.Exit(test.Finish(m.Run()))
 }
@@ -13,6 +13,7 @@ import (
 
 func TestRegisterWallet(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagSmoke)
 
 	t.Parallel()
 
@@ -1,8 +1,7 @@
 package api_tests
 
 import (
-	"crypto/rand"
-	"math/big"
+	"strconv"
 	"testing"
 	"time"
 
@@ -93,10 +92,9 @@ func TestReplaceBlobber(testSetup *testing.T) {
 		newBlobberID := getNotUsedStorageNodeID(allocationBlobbers.Blobbers, allocation.Blobbers)
 		require.NotZero(t, newBlobberID, "Old blobber ID contains zero value")
 
-		result, err := rand.Int(rand.Reader, big.NewInt(10))
-		require.Nil(t, err)
+		result := strconv.Itoa(t.Rand().Intn(10))
 
-		apiClient.UpdateAllocationBlobbers(t, wallet, newBlobberID, result.String(), allocationID, client.TxUnsuccessfulStatus)
+		apiClient.UpdateAllocationBlobbers(t, wallet, newBlobberID, result, allocationID, client.TxUnsuccessfulStatus)
 
 		var numberOfBlobbersAfter int
 
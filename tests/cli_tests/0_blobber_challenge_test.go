@@ -42,7 +42,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 	require.Greater(t, len(sharderBaseURLs), 0, "No sharder URLs found.")
 
 	blobberList := []climodel.BlobberInfo{}
-	output, err = listBlobbers(t, configPath, "--json")
+	output, err = listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 	require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 	require.Len(t, output, 1)
 
@@ -67,7 +67,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 		filesize := 2 * MB
 		filename := generateRandomTestFileName(t)
 
-		err = createFileWithSize(filename, int64(filesize))
+		err = createFileWithSize(t, filename, int64(filesize))
 		require.Nil(t, err)
 
 		output, err = uploadFile(t, configPath, map[string]interface{}{
@@ -91,7 +91,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 		filesize := 2 * MB
 		filename := generateRandomTestFileName(t)
 
-		err := createFileWithSize(filename, int64(filesize))
+		err := createFileWithSize(t, filename, int64(filesize))
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -134,7 +134,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 		filesize := 2 * MB
 		filename := generateRandomTestFileName(t)
 
-		err := createFileWithSize(filename, int64(filesize))
+		err := createFileWithSize(t, filename, int64(filesize))
 		require.Nil(t, err, "error creating file")
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -177,7 +177,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 		filesize := 2 * MB
 		filename := generateRandomTestFileName(t)
 
-		err := createFileWithSize(filename, int64(filesize))
+		err := createFileWithSize(t, filename, int64(filesize))
 		require.Nil(t, err, "error creating file")
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -219,7 +219,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 		filesize := 2 * MB
 		filename := generateRandomTestFileName(t)
 
-		err := createFileWithSize(filename, int64(filesize))
+		err := createFileWithSize(t, filename, int64(filesize))
 		require.Nil(t, err, "error creating file")
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -262,7 +262,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 		filesize := 2 * MB
 		filename := generateRandomTestFileName(t)
 
-		err := createFileWithSize(filename, int64(filesize))
+		err := createFileWithSize(t, filename, int64(filesize))
 		require.Nil(t, err, "error creating file")
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -285,7 +285,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 		openChallengesBefore := openChallengesForAllBlobbers(t, sharderBaseURLs, blobbers)
 
 		localfile := generateRandomTestFileName(t)
-		err = createFileWithSize(localfile, 2*MB)
+		err = createFileWithSize(t, localfile, 2*MB)
 		require.Nil(t, err)
 
 		output, err = updateFileWithWallet(t, escapedTestName(t), configPath, map[string]interface{}{
@@ -309,7 +309,7 @@ func TestBlobberChallenge(testSetup *testing.T) {
 		filesize := 2 * MB
 		filename := generateRandomTestFileName(t)
 
-		err := createFileWithSize(filename, int64(filesize))
+		err := createFileWithSize(t, filename, int64(filesize))
 		require.Nil(t, err, "error creating file")
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
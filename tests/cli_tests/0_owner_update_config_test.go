@@ -13,6 +13,7 @@ import (
 
 func TestOwnerUpdate(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagOwnerConfig)
 
 	if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 		t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
@@ -0,0 +1,67 @@
+package cli_tests
+
+import (
+	"strings"
+
+	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/stretchr/testify/require"
+)
+
+type allocationFixtureValue struct {
+	ID     string
+	Wallet string
+}
+
+// newFundedWalletFixture registers a wallet and pours tokens into it, returning the wallet name.
+func newFundedWalletFixture(name string, scope test.Scope, tokens float64) *test.Fixture[string] {
+	return &test.Fixture[string]{
+		Name:  name + " wallet",
+		Scope: scope,
+		Setup: func(t *test.SystemTest) string {
+			walletName := fixtureWalletName(t, name, scope)
+			registerWalletWithTokens(t, configPath, walletName, tokens)
+			return walletName
+		},
+	}
+}
+
+// newAllocationFixture creates an allocation owned by the wallet fixture and cancels it once its scope ends.
+// The number of data and parity shards and any other allocation flags are passed as params.
+func newAllocationFixture(name string, scope test.Scope, wallet *test.Fixture[string], params map[string]interface{}) *test.Fixture[allocationFixtureValue] {
+	return &test.Fixture[allocationFixtureValue]{
+		Name:  name + " allocation",
+		Scope: scope,
+		Setup: func(t *test.SystemTest) allocationFixtureValue {
+			walletName := wallet.Get(t)
+
+			options := map[string]interface{}{"expire": "1h", "size": "10000", "lock": "0.5"}
+			for k, v := range params {
+				options[k] = v
+			}
+
+			output, err := createNewAllocationForWallet(t, walletName, configPath, createParams(options))
+			require.Nil(t, err, "create new allocation failed", strings.Join(output, "\n"))
+			require.Len(t, output, 1)
+
+			allocationID, err := getAllocationID(output[0])
+			require.Nil(t, err, "could not get allocation ID", strings.Join(output, "\n"))
+
+			return allocationFixtureValue{ID: allocationID, Wallet: walletName}
+		},
+		Teardown: func(t *test.SystemTest, allocation allocationFixtureValue) {
+			_, _ = cancelAllocationForWallet(t, allocation.Wallet, configPath, allocation.ID, false)
+		},
+	}
+}
+
+// fixtureWalletName names wallets after the test case or top-level test sharing them, so they never collide between scopes.
+func fixtureWalletName(t *test.SystemTest, name string, scope test.Scope) string {
+	switch scope {
+	case test.SuiteScope:
+		return "suite_" + name
+	case test.TestScope:
+		return strings.SplitN(escapedTestName(t), "-", 2)[0] + "_" + name
+	default:
+		return escapedTestName(t) + "_" + name
+	}
+}
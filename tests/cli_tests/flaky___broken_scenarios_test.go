@@ -39,13 +39,13 @@ func Test___FlakyBrokenScenarios(testSetup *testing.T) {
 		allocationID := setupAllocation(t, configPath, map[string]interface{}{"size": 2 * MB})
 		createAllocationTestTeardown(t, allocationID)
 
-		localFolderRoot := filepath.Join(os.TempDir(), "to-sync", cliutils.RandomAlphaNumericString(10))
+		localFolderRoot := filepath.Join(os.TempDir(), "to-sync", cliutils.RandomAlphaNumericString(t, 10))
 		err := os.MkdirAll(localFolderRoot, os.ModePerm)
 		require.Nil(t, err, "Error in creating the folders", localFolderRoot)
 		defer os.RemoveAll(localFolderRoot)
 
 		// Create a local file in root
-		err = createFileWithSize(filepath.Join(localFolderRoot, "root.txt"), 32*KB)
+		err = createFileWithSize(t, filepath.Join(localFolderRoot, "root.txt"), 32*KB)
 		require.Nil(t, err, "Cannot create a local file")
 
 		output, err := syncFolder(t, configPath, map[string]interface{}{
@@ -74,7 +74,7 @@ func Test___FlakyBrokenScenarios(testSetup *testing.T) {
 		require.NotNil(t, file_initial, "sync error, file 'root.txt' must be uploaded to allocation", files)
 
 		// Update the local file in root
-		err = createFileWithSize(filepath.Join(localFolderRoot, "root.txt"), 128*KB)
+		err = createFileWithSize(t, filepath.Join(localFolderRoot, "root.txt"), 128*KB)
 		require.Nil(t, err, "Cannot update the local file")
 
 		output, err = getDifferences(t, configPath, map[string]interface{}{
@@ -168,9 +168,9 @@ func Test___FlakyBrokenScenarios(testSetup *testing.T) {
 		}
 
 		// Update the local files in sub folders
-		err = createFileWithSize(filepath.Join(rootLocalFolder, "folder1", "file-in-folder1.txt"), 128*KB)
+		err = createFileWithSize(t, filepath.Join(rootLocalFolder, "folder1", "file-in-folder1.txt"), 128*KB)
 		require.Nil(t, err, "Cannot update the local file")
-		err = createFileWithSize(filepath.Join(rootLocalFolder, "folder2", "file-in-folder2.txt"), 128*KB)
+		err = createFileWithSize(t, filepath.Join(rootLocalFolder, "folder2", "file-in-folder2.txt"), 128*KB)
 		require.Nil(t, err, "Cannot update the local file")
 
 		output, err = getDifferences(t, configPath, map[string]interface{}{
@@ -277,11 +277,11 @@ func Test___FlakyBrokenScenarios(testSetup *testing.T) {
 		require.NotNil(t, excludedFile_initial, "sync error, file '%s' must be uploaded to allocation", excludedFile_initial, files)
 
 		// Update the local files
-		err = createFileWithSize(filepath.Join(rootLocalFolder, excludedFolderName, excludedFileName), 128*KB)
+		err = createFileWithSize(t, filepath.Join(rootLocalFolder, excludedFolderName, excludedFileName), 128*KB)
 		require.Nil(t, err, "Cannot change the file size")
-		err = createFileWithSize(filepath.Join(rootLocalFolder, includedFolderName, includedFileName), 128*KB)
+		err = createFileWithSize(t, filepath.Join(rootLocalFolder, includedFolderName, includedFileName), 128*KB)
 		require.Nil(t, err, "Cannot change the file size")
-		err = createFileWithSize(filepath.Join(rootLocalFolder, "abc.txt"), 128*KB)
+		err = createFileWithSize(t, filepath.Join(rootLocalFolder, "abc.txt"), 128*KB)
 		require.Nil(t, err, "Cannot change the file size")
 
 		output, err = getDifferences(t, configPath, map[string]interface{}{
@@ -357,7 +357,7 @@ func Test___FlakyBrokenScenarios(testSetup *testing.T) {
 		require.Equal(t, 0.8, intToZCN(initialAllocation.WritePool))
 
 		filename := generateRandomTestFileName(t)
-		err = createFileWithSize(filename, 1024*5)
+		err = createFileWithSize(t, filename, 1024*5)
 		require.Nil(t, err, "error while generating file: ", err)
 
 		// Get expected upload cost
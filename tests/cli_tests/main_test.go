@@ -75,6 +75,38 @@ const (
 	sharder02NodeDelegateWalletName = "wallets/sharder02_node_delegate"
 )
 
+// sharedWallets are kept when cleaning up the config dir and copied as 0444 into every workspace
+var sharedWallets = []string{
+	zcnscOwner + "_wallet.json",
+	scOwnerWallet + "_wallet.json",
+	blobberOwnerWallet + "_wallet.json",
+	miner01NodeDelegateWalletName + "_wallet.json",
+	miner02NodeDelegateWalletName + "_wallet.json",
+	miner03NodeDelegateWalletName + "_wallet.json",
+	sharder01NodeDelegateWalletName + "_wallet.json",
+	sharder02NodeDelegateWalletName + "_wallet.json",
+}
+
+func isSharedWallet(path string) bool {
+	for _, wallet := range sharedWallets {
+		if strings.HasSuffix(filepath.ToSlash(path), wallet) {
+			return true
+		}
+	}
+	return false
+}
+
+// newWorkspace returns a config dir of its own for the test case, with the shared wallets copied as 0444 at their usual paths
+func newWorkspace(t *test.SystemTest) *cliutils.Workspace {
+	var wallets []string
+	for _, wallet := range sharedWallets {
+		if _, err := os.Stat(filepath.Join(configDir, wallet)); err == nil {
+			wallets = append(wallets, wallet)
+		}
+	}
+	return cliutils.NewWorkspace(t, configDir, configPath, wallets...)
+}
+
 var (
 	miner01ID   string
 	miner02ID   string
@@ -120,18 +152,11 @@ func TestMain(m *testing.M) {
 
 	configDir, _ = filepath.Abs(configDir)
 
+	// tests not using a workspace still share ./config, so their files are removed before the run
 	if !strings.EqualFold(strings.TrimSpace(os.Getenv("SKIP_CONFIG_CLEANUP")), "true") {
 		if files, err := filepath.Glob("./config/*.json"); err == nil {
 			for _, f := range files {
-				// skip deleting the SC owner wallet and blobber owner wallet
-				if strings.HasSuffix(f, zcnscOwner+"_wallet.json") ||
-					strings.HasSuffix(f, scOwnerWallet+"_wallet.json") ||
-					strings.HasSuffix(f, blobberOwnerWallet+"_wallet.json") ||
-					strings.HasSuffix(f, miner01NodeDelegateWalletName+"_wallet.json") ||
-					strings.HasSuffix(f, miner02NodeDelegateWalletName+"_wallet.json") ||
-					strings.HasSuffix(f, miner03NodeDelegateWalletName+"_wallet.json") ||
-					strings.HasSuffix(f, sharder01NodeDelegateWalletName+"_wallet.json") ||
-					strings.HasSuffix(f, sharder02NodeDelegateWalletName+"_wallet.json") {
+				if isSharedWallet(f) {
 					continue
 				}
 				_ = os.Remove(f)
@@ -153,6 +178,9 @@ func TestMain(m *testing.M) {
 
 	setupConfig()
 
+	cliutils.DetectCLI("./zbox", "./config", configPath)
+	cliutils.DetectCLI("./zwallet", "./config", configPath)
+
 	tenderlyClient = tenderly.NewClient(ethereumNodeURL)
 
 	snapshotHash, err := tenderlyClient.CreateSnapshot()
@@ -165,7 +193,7 @@ func TestMain(m *testing.M) {
 		log.Fatalln(err)
 	}
 
-	exitRun := m.Run()
+	exitRun := test.Finish(m.Run())
 
 	err = tenderlyClient.Revert(snapshotHash)
 	if err != nil {
@@ -4,7 +4,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"math"
 	"net/http"
 	"reflect"
 	"sort"
@@ -28,7 +27,9 @@ const (
 
 func TestMinerBlockRewards(testSetup *testing.T) { // nolint:gocyclo // team preference is to have codes all within test.
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagTokenomics, test.TagSlow)
 	t.Skip("Skip till chain-side bugs are resolved")
+	t.HoldLocks(test.Shared(test.MinerSCConfigLock, test.GlobalConfigLock))
 
 	// Take a snapshot of the chains miners, then wait a few seconds, take another snapshot.
 	// Examine the rewards paid between the two snapshot and confirm the self-consistency
@@ -67,8 +68,8 @@ func TestMinerBlockRewards(testSetup *testing.T) { // nolint:gocyclo // team pre
 		)
 
 		time.Sleep(time.Second) // give time for last round to be saved
-		history := cliutil.NewHistory(startRound, endRound)
-		history.Read(t, sharderUrl, false)
+		history := cliutil.NewHistory(startRound, endRound).WithCache(cliutil.OpenHistoryCache(t, sharderUrl))
+		history.Read(t, cliutil.Sharder(sharderUrl), false)
 
 		balanceMinerRewards(
 			t, startRound, endRound, minerIds, beforeMiners.Nodes, afterMiners.Nodes, history,
@@ -88,7 +89,7 @@ func balanceMinerRewards(
 		"epoch changed during test, start %v finish %v",
 		startRound/int64(minerScConfig["epoch"]), endRound/int64(minerScConfig["epoch"]))
 
-	minerBlockReward, _ := blockRewards(startRound, minerScConfig)
+	minerBlockReward, _ := cliutil.NewRewardConfig(minerScConfig).BlockRewards(startRound)
 
 	checkMinerBlockRewards(
 		t,
@@ -102,6 +103,8 @@ func balanceMinerRewards(
 		t, startRound+1, endRound-1, history,
 	)
 
+	requireRewardsReconciled(t, minerScConfig, beforeMiners, nil, history, startRound+1, endRound-1)
+
 	checkMinerDelegatePoolBlockRewards(
 		t,
 		minerIds,
@@ -411,14 +414,18 @@ func getMinerScMap(t *test.SystemTest) map[string]float64 {
 	return floatMap
 }
 
-func blockRewards(round int64, minerScConfig map[string]float64) (minerReward, sharderReward int64) {
-	epoch := round / int64(minerScConfig["epoch"])
-	epochDecline := 1.0 - minerScConfig["reward_decline_rate"]
-	declineRate := math.Pow(epochDecline, float64(epoch))
-	blockReward := (minerScConfig["block_reward"] * float64(TOKEN_UNIT)) * declineRate
-	minerReward = int64(blockReward * minerScConfig["share_ratio"])
-	sharderReward = int64(blockReward) - minerReward
-	return minerReward, sharderReward
+// requireRewardsReconciled requires the provider and delegate rewards of the given miners and sharders
+// to match the miner smart contract config in every round from start to end.
+func requireRewardsReconciled(
+	t *test.SystemTest,
+	minerScConfig map[string]float64,
+	miners, sharders []climodel.Node,
+	history *cliutil.ChainHistory,
+	start, end int64,
+) {
+	reconciler := cliutil.NewRewardReconciler(cliutil.NewRewardConfig(minerScConfig), miners, sharders)
+	report := reconciler.Reconcile(t, history, start, end)
+	require.True(t, report.Reconciled(), report.String())
 }
 
 func getSharderUrl(t *test.SystemTest) string {
@@ -441,7 +448,12 @@ func getSharderUrl(t *test.SystemTest) string {
 
 func getNode(t *test.SystemTest, cliConfigFilename, nodeID string) ([]string, error) {
 	t.Logf("getting a miner or sharder node...")
-	return cliutil.RunCommand(t, "./zwallet mn-info --silent --id "+nodeID+" --wallet "+escapedTestName(t)+"_wallet.json --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	return cliutil.Zwallet("mn-info").
+		WithFlag("id", nodeID).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func getSortedMinerIds(t *test.SystemTest, sharderBaseURL string) []string {
@@ -450,8 +462,8 @@ func getSortedMinerIds(t *test.SystemTest, sharderBaseURL string) []string {
 
 func getSortedNodeIds(t *test.SystemTest, endpoint, sharderBaseURL string) []string {
 	t.Logf("getting miner or sharder nodes...")
-	url := sharderBaseURL + "/v1/screst/" + minerSmartContractAddress + "/" + endpoint
-	nodeList := cliutil.ApiGetRetries[climodel.NodeList](t, url, nil, restApiRetries)
+	path := "/v1/screst/" + minerSmartContractAddress + "/" + endpoint
+	nodeList := cliutil.ApiGetRetries[climodel.NodeList](t, cliutil.Sharder(sharderBaseURL), path, nil, restApiRetries)
 	var nodeIds []string
 	for i := range nodeList.Nodes {
 		nodeIds = append(nodeIds, nodeList.Nodes[i].ID)
@@ -464,14 +476,14 @@ func getSortedNodeIds(t *test.SystemTest, endpoint, sharderBaseURL string) []str
 
 func getNodes(t *test.SystemTest, ids []string, sharderBaseURL string) climodel.NodeList {
 	t.Logf("getting miner or sharder nodes...")
-	url := sharderBaseURL + "/test/screst/nodeStat"
+	path := "/test/screst/nodeStat"
 	params := map[string]string{
 		"include_delegates": "true",
 	}
 	var nodes climodel.NodeList
 	for _, id := range ids {
 		params["id"] = id
-		nodes.Nodes = append(nodes.Nodes, *cliutil.ApiGetRetries[climodel.Node](t, url, params, restApiRetries))
+		nodes.Nodes = append(nodes.Nodes, *cliutil.ApiGetRetries[climodel.Node](t, cliutil.Sharder(sharderBaseURL), path, params, restApiRetries))
 	}
 	return nodes
 }
@@ -482,7 +494,13 @@ func getSharders(t *test.SystemTest, cliConfigFilename string) ([]string, error)
 
 func getShardersForWallet(t *test.SystemTest, cliConfigFilename, wallet string) ([]string, error) {
 	t.Logf("list sharder nodes...")
-	return cliutil.RunCommandWithRawOutput("./zwallet ls-sharders --active --json --silent --wallet " + wallet + "_wallet.json --configDir ./config --config " + cliConfigFilename)
+	result, err := cliutil.Zwallet("ls-sharders").
+		WithFlags(cliutil.Flags{"active": nil, "json": nil}).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename).
+		Execute(t.Context())
+	return result.CombinedLines(), err
 }
 
 func getNodeBaseURL(host string, port int) string {
@@ -491,7 +509,13 @@ func getNodeBaseURL(host string, port int) string {
 
 func getMinersForWallet(t *test.SystemTest, cliConfigFilename, wallet string) ([]string, error) {
 	t.Log("list miner nodes...")
-	return cliutil.RunCommandWithRawOutput("./zwallet ls-miners --active --json --silent --wallet " + wallet + "_wallet.json --configDir ./config --config " + cliConfigFilename)
+	result, err := cliutil.Zwallet("ls-miners").
+		WithFlags(cliutil.Flags{"active": nil, "json": nil}).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename).
+		Execute(t.Context())
+	return result.CombinedLines(), err
 }
 
 func apiGetBalance(t *test.SystemTest, sharderBaseURL, clientID string) (*http.Response, error) {
@@ -505,7 +529,12 @@ func apiGetBlock(t *test.SystemTest, sharderBaseURL string, round int64) (*http.
 }
 func getMiners(t *test.SystemTest, cliConfigFilename string) ([]string, error) {
 	t.Log("Get miners...")
-	return cliutil.RunCommand(t, "./zwallet ls-miners --active --json --silent --wallet "+escapedTestName(t)+"_wallet.json --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	return cliutil.Zwallet("ls-miners").
+		WithFlags(cliutil.Flags{"active": nil, "json": nil}).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func getStartAndEndRounds(
@@ -14,7 +14,9 @@ import (
 
 func TestMinerFeeRewards(testSetup *testing.T) { // nolint:gocyclo // team preference is to have codes all within test.
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagTokenomics, test.TagSlow)
 	t.Skip("Skip till chain-side bugs are resolved")
+	t.HoldLocks(test.Shared(test.MinerSCConfigLock))
 
 	// Take a snapshot of the chains miners, repeat a transaction with a fee a few times,
 	// take another snapshot.
@@ -63,8 +65,8 @@ func TestMinerFeeRewards(testSetup *testing.T) { // nolint:gocyclo // team prefe
 
 		time.Sleep(time.Second) // give time for last round to be saved
 
-		history := cliutil.NewHistory(startRound, endRound)
-		history.Read(t, sharderUrl, true)
+		history := cliutil.NewHistory(startRound, endRound).WithCache(cliutil.OpenHistoryCache(t, sharderUrl))
+		history.Read(t, cliutil.Sharder(sharderUrl), true)
 
 		balanceMinerIncome(
 			t, startRound, endRound, minerIds, beforeMiners.Nodes, afterMiners.Nodes, history,
@@ -87,9 +89,7 @@ func balanceMinerIncome(
 		beforeMiners, afterMiners,
 		history,
 	)
-	checkMinerFeeRewardFrequency(
-		t, startRound+1, endRound-1, history,
-	)
+	requireRewardsReconciled(t, minerScConfig, beforeMiners, nil, history, startRound+1, endRound-1)
 	checkMinerDelegatePoolFeeAmounts(
 		t,
 		minerIds,
@@ -165,33 +165,6 @@ func checkMinerFeeAmounts(
 	}
 }
 
-// checkCountOfFeePayments
-// Each round there should be zero or one fee reward payment depending on the count of
-// transaction with a fee. This should be paid to the blocks' miner and its delegates.
-func checkMinerFeeRewardFrequency(
-	t *test.SystemTest,
-	start, end int64,
-	history *cliutil.ChainHistory,
-) {
-	t.Log("checking number of fee payments...")
-	for round := start; round <= end; round++ {
-		roundHistory := history.RoundHistory(t, round)
-		isAFeePayment := history.FeesForRound(t, round) > 0
-		foundFeeRewardPayment := false
-		for _, pReward := range roundHistory.ProviderRewards {
-			if pReward.RewardType == climodel.FeeRewardMiner {
-				require.Falsef(t, foundFeeRewardPayment, "round %d, block reward already paid, only pay miner block rewards once", round)
-				foundFeeRewardPayment = true
-				require.Equal(t, pReward.ProviderId, roundHistory.Block.MinerID,
-					"round %d, block reward paid to %s, should only be paid to round lottery winner %s",
-					round, pReward.ProviderId, roundHistory.Block.MinerID)
-			}
-		}
-		require.EqualValues(t, foundFeeRewardPayment, isAFeePayment,
-			"rond %d, incorrect number of miner fee reward payments", round)
-	}
-}
-
 // checkMinerDelegatePoolRewards
 // Each round confirm payments to delegates or the blocks winning miner.
 // There should be exactly `num_miner_delegates_rewarded` delegates rewarded each round,
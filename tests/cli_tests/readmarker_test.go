@@ -76,7 +76,7 @@ func TestReadMarker(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, filesize)
+		err := createFileWithSize(t, filename, filesize)
 		require.Nil(t, err)
 
 		// Upload parameters
@@ -366,11 +366,11 @@ func TestReadMarker(testSetup *testing.T) {
 }
 
 func CountReadMarkers(t *test.SystemTest, allocationId, sharderBaseUrl string) *climodel.ReadMarkersCount {
-	url := fmt.Sprintf(sharderBaseUrl + "/v1/screst/" + cliutils.StorageScAddress + "/count_readmarkers")
+	path := "/v1/screst/" + cliutils.StorageScAddress + "/count_readmarkers"
 	params := map[string]string{
 		"allocation_id": allocationId,
 	}
-	return cliutils.ApiGet[climodel.ReadMarkersCount](t, url, params)
+	return cliutils.ApiGet[climodel.ReadMarkersCount](t, cliutils.Sharder(sharderBaseUrl), path, params)
 }
 
 func GetReadMarkers(t *test.SystemTest, allocationId, sharderBaseUrl string) []climodel.ReadMarker {
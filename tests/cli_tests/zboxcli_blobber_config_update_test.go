@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -12,6 +11,7 @@ import (
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -248,7 +248,7 @@ func TestBlobberConfigUpdate(testSetup *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		output, err = updateBlobberInfo(t, configPath, "")
+		output, err = updateBlobberInfo(t, configPath, nil)
 		require.NotNil(t, err, strings.Join(output, "\n"))
 		require.Len(t, output, 26)
 		require.Equal(t, "Error: required flag(s) \"blobber_id\" not set", output[0])
@@ -268,7 +268,12 @@ func TestBlobberConfigUpdate(testSetup *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-		output, err = cliutils.RunCommand(t, fmt.Sprintf("./zbox bl-update %s --silent --wallet %s_wallet.json --configDir ./config --config %s", createParams(map[string]interface{}{"blobber_id": intialBlobberInfo.ID}), escapedTestName(t), configPath), 1, time.Second*2)
+		output, err = cliutils.Zbox("bl-update").
+			WithFlag("blobber_id", intialBlobberInfo.ID).
+			WithSilent().
+			WithWallet(escapedTestName(t)+"_wallet.json").
+			WithConfig(configPath).
+			Run(t, 1, time.Second*2)
 		require.NotNil(t, err, strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		require.Equal(t, "update_blobber_settings_failed: access denied, allowed for delegate_wallet owner only",
@@ -369,29 +374,42 @@ func TestBlobberConfigUpdate(testSetup *testing.T) {
 		err = json.Unmarshal([]byte(output[0]), &finalBlobberInfo)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		require.Equal(t, newWritePrice, intToZCN(finalBlobberInfo.Terms.Write_price))
-		require.Equal(t, newServiceCharge, finalBlobberInfo.StakePoolSettings.ServiceCharge)
-		require.Equal(t, newReadPrice, intToZCN(finalBlobberInfo.Terms.Read_price))
-		require.Equal(t, newNumberOfDelegates, finalBlobberInfo.StakePoolSettings.MaxNumDelegates)
-		require.Equal(t, newMaxOfferDuration, finalBlobberInfo.Terms.Max_offer_duration)
-		require.Equal(t, newCapacity, finalBlobberInfo.Capacity)
-		require.Equal(t, newMinLockDemand, finalBlobberInfo.Terms.Min_lock_demand)
+		// check every setting, so a single run reports all settings which were not updated
+		assert.Equal(t.Check(), newWritePrice, intToZCN(finalBlobberInfo.Terms.Write_price), "write price")
+		assert.Equal(t.Check(), newServiceCharge, finalBlobberInfo.StakePoolSettings.ServiceCharge, "service charge")
+		assert.Equal(t.Check(), newReadPrice, intToZCN(finalBlobberInfo.Terms.Read_price), "read price")
+		assert.Equal(t.Check(), newNumberOfDelegates, finalBlobberInfo.StakePoolSettings.MaxNumDelegates, "number of delegates")
+		assert.Equal(t.Check(), newMaxOfferDuration, finalBlobberInfo.Terms.Max_offer_duration, "max offer duration")
+		assert.Equal(t.Check(), newCapacity, finalBlobberInfo.Capacity, "capacity")
+		assert.Equal(t.Check(), newMinLockDemand, finalBlobberInfo.Terms.Min_lock_demand, "min lock demand")
 		minStake, err = finalBlobberInfo.StakePoolSettings.MinStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, newMinStake, intToZCN(minStake))
+		if assert.Nil(t.Check(), err, "min stake") {
+			assert.Equal(t.Check(), newMinStake, intToZCN(minStake), "min stake")
+		}
 		maxStake, err = finalBlobberInfo.StakePoolSettings.MaxStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, newMaxStake, intToZCN(maxStake))
-		require.Equal(t, newIsAvailable, finalBlobberInfo.IsAvailable)
+		if assert.Nil(t.Check(), err, "max stake") {
+			assert.Equal(t.Check(), newMaxStake, intToZCN(maxStake), "max stake")
+		}
+		assert.Equal(t.Check(), newIsAvailable, finalBlobberInfo.IsAvailable, "availability")
 	})
 }
 
-func getBlobberInfo(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func getBlobberInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Log("Requesting blobber info...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zbox bl-info %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zbox("bl-info").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
-func updateBlobberInfo(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func updateBlobberInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Log("Updating blobber info...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zbox bl-update %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, blobberOwnerWallet, cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zbox("bl-update").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(blobberOwnerWallet+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -23,6 +22,8 @@ func TestCancelAllocation(testSetup *testing.T) {
 
 	t.Parallel()
 
+	otherOwnerAllocation := newAllocationFixture("other_owner", test.TestScope, newFundedWalletFixture("other_owner", test.TestScope, 1.0), nil)
+
 	t.Run("Cancel allocation immediately should work", func(t *test.SystemTest) {
 		allocationID := setupAllocation(t, configPath)
 
@@ -33,21 +34,22 @@ func TestCancelAllocation(testSetup *testing.T) {
 	})
 
 	t.Run("No allocation param should fail", func(t *test.SystemTest) {
-		cmd := fmt.Sprintf(
-			"./zbox alloc-cancel --silent "+
-				"--wallet %s --configDir ./config --config %s",
-			escapedTestName(t)+"_wallet.json",
-			configPath,
-		)
-
-		output, err := cliutils.RunCommandWithoutRetry(cmd)
-		require.Error(t, err, "expected error canceling allocation", strings.Join(output, "\n"))
-		require.Len(t, output, 4)
-		require.Equal(t, "Error: allocation flag is missing", output[len(output)-1])
+		result, err := cliutils.Zbox("alloc-cancel").
+			WithSilent().
+			WithWallet(escapedTestName(t) + "_wallet.json").
+			WithConfig(configPath).
+			Execute(t.Context())
+		require.Error(t, err, "expected error canceling allocation", string(result.Combined))
+		require.Equal(t, 1, result.ExitCode, string(result.Combined))
+		require.Len(t, result.Sanitized(), 4)
+
+		stderr := result.StderrLines()
+		require.NotEmpty(t, stderr, string(result.Combined))
+		require.Equal(t, "Error: allocation flag is missing", stderr[len(stderr)-1])
 	})
 
 	t.Run("Cancel Other's Allocation Should Fail", func(t *test.SystemTest) {
-		otherAllocationID := setupAllocationWithWallet(t, escapedTestName(t)+"_other_wallet.json", configPath)
+		otherAllocationID := otherOwnerAllocation.Get(t).ID
 
 		// otherAllocationID should not be cancelable from this level
 		output, err := cancelAllocation(t, configPath, otherAllocationID, false)
@@ -89,18 +91,20 @@ func TestCancelAllocation(testSetup *testing.T) {
 }
 
 func cancelAllocation(t *test.SystemTest, cliConfigFilename, allocationID string, retry bool) ([]string, error) {
+	return cancelAllocationForWallet(t, escapedTestName(t), cliConfigFilename, allocationID, retry)
+}
+
+func cancelAllocationForWallet(t *test.SystemTest, wallet, cliConfigFilename, allocationID string, retry bool) ([]string, error) {
 	t.Logf("Canceling allocation...")
-	cmd := fmt.Sprintf(
-		"./zbox alloc-cancel --allocation %s --silent "+
-			"--wallet %s --configDir ./config --config %s",
-		allocationID,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("alloc-cancel").
+		WithFlag("allocation", allocationID).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
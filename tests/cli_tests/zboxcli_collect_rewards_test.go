@@ -33,7 +33,7 @@ func TestBlobberCollectRewards(testSetup *testing.T) {
 		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
 
 		blobbers := []climodel.BlobberInfo{}
-		output, err = listBlobbers(t, configPath, "--json")
+		output, err = listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		err = json.Unmarshal([]byte(output[0]), &blobbers)
@@ -123,7 +123,7 @@ func TestBlobberCollectRewards(testSetup *testing.T) {
 		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
 
 		blobbers := []climodel.BlobberInfo{}
-		output, err = listBlobbers(t, configPath, "--json")
+		output, err = listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		err = json.Unmarshal([]byte(output[0]), &blobbers)
@@ -160,7 +160,7 @@ func TestBlobberCollectRewards(testSetup *testing.T) {
 		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
 
 		blobbers := []climodel.BlobberInfo{}
-		output, err = listBlobbers(t, configPath, "--json")
+		output, err = listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		err = json.Unmarshal([]byte(output[0]), &blobbers)
@@ -216,7 +216,7 @@ func TestValidatorCollectRewards(testSetup *testing.T) {
 		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
 
 		var validators []climodel.Validator
-		output, err = listValidators(t, configPath, "--json")
+		output, err = listValidators(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing validators", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		err = json.Unmarshal([]byte(output[0]), &validators)
@@ -308,7 +308,7 @@ func TestValidatorCollectRewards(testSetup *testing.T) {
 		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
 
 		validators := []climodel.Validator{}
-		output, err = listValidators(t, configPath, "--json")
+		output, err = listValidators(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		err = json.Unmarshal([]byte(output[0]), &validators)
@@ -344,7 +344,7 @@ func TestValidatorCollectRewards(testSetup *testing.T) {
 		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
 
 		validators := []climodel.Validator{}
-		output, err = listValidators(t, configPath, "--json")
+		output, err = listValidators(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing validators", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		err = json.Unmarshal([]byte(output[0]), &validators)
@@ -383,12 +383,16 @@ func TestValidatorCollectRewards(testSetup *testing.T) {
 	})
 }
 
-func collectRewards(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func collectRewards(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("collecting rewards...")
-	cmd := fmt.Sprintf("./zbox collect-reward %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename)
+	cmd := cliutils.Zbox("collect-reward").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -1,8 +1,6 @@
 package cli_tests
 
 import (
-	"encoding/json"
-	"fmt"
 	"path/filepath"
 	"regexp"
 	"strings"
@@ -11,6 +9,7 @@ import (
 
 	"github.com/0chain/system_test/internal/api/util/test"
 
+	cliclient "github.com/0chain/system_test/internal/cli/client"
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
 	"github.com/stretchr/testify/require"
@@ -109,7 +108,7 @@ func uploadRandomlyGeneratedFile(t *test.SystemTest, allocationID, remotePath st
 
 func uploadRandomlyGeneratedFileWithWallet(t *test.SystemTest, walletName, allocationID, remotePath string, fileSize int64) string {
 	filename := generateRandomTestFileName(t)
-	err := createFileWithSize(filename, fileSize)
+	err := createFileWithSize(t, filename, fileSize)
 	require.Nil(t, err)
 
 	if !strings.HasSuffix(remotePath, "/") {
@@ -151,7 +150,7 @@ func updateFileWithRandomlyGeneratedData(t *test.SystemTest, allocationID, remot
 
 func updateFileWithRandomlyGeneratedDataWithWallet(t *test.SystemTest, walletName, allocationID, remotepath string, size int64) string {
 	localfile := generateRandomTestFileName(t)
-	err := createFileWithSize(localfile, size)
+	err := createFileWithSize(t, localfile, size)
 	require.Nil(t, err)
 
 	output, err := updateFileWithWallet(t, walletName, configPath, map[string]interface{}{
@@ -165,18 +164,15 @@ func updateFileWithRandomlyGeneratedDataWithWallet(t *test.SystemTest, walletNam
 
 func renameFile(t *test.SystemTest, cliConfigFilename string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Renaming file...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox rename %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-	)
-
+	cmd := cliutils.Zbox("rename").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*20)
+		return cmd.Run(t, 3, time.Second*20)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -187,38 +183,37 @@ func updateFile(t *test.SystemTest, cliConfigFilename string, param map[string]i
 func updateFileWithWallet(t *test.SystemTest, walletName, cliConfigFilename string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Updating file...")
 
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox update %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		walletName+"_wallet.json",
-		cliConfigFilename,
-	)
-
+	cmd := cliutils.Zbox("update").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*20)
+		return cmd.Run(t, 3, time.Second*20)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func getAllocation(t *test.SystemTest, allocationID string) (allocation climodel.Allocation) {
-	output, err := getAllocationWithRetry(t, configPath, allocationID, 1)
+func getAllocation(t *test.SystemTest, allocationID string) climodel.Allocation {
+	allocation, err := zboxClient(t).GetAllocation(t, allocationID)
 	require.Nil(t, err, "error fetching allocation")
-	require.Greater(t, len(output), 0, "gettting allocation - output is empty unexpectedly")
-	err = json.Unmarshal([]byte(output[0]), &allocation)
-	require.Nil(t, err, "error unmarshalling allocation json")
-	return
+	return allocation
+}
+
+// zboxClient returns the typed zbox facade running as the wallet of the test case
+func zboxClient(t *test.SystemTest) *cliclient.Zbox {
+	return cliclient.NewZbox(configPath, escapedTestName(t)+"_wallet.json")
 }
 
 func getAllocationWithRetry(t *test.SystemTest, cliConfigFilename, allocationID string, retry int) ([]string, error) {
 	t.Logf("Get Allocation...")
-	output, err := cliutils.RunCommand(t, fmt.Sprintf(
-		"./zbox getallocation --allocation %s --json --silent --wallet %s --configDir ./config --config %s",
-		allocationID,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename), retry, time.Second*5)
-	return output, err
+	return cliutils.Zbox("getallocation").
+		WithFlags(cliutils.Flags{"allocation": allocationID, "json": nil}).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, retry, time.Second*5)
 }
 
 // ConvertToToken converts the value to ZCN tokens
@@ -47,6 +47,7 @@ func TestCreateAllocationFreeStorage(testSetup *testing.T) {
 	if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 		t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 	}
+	t.HoldLocks(test.Shared(test.StorageSCConfigLock))
 
 	assigner := escapedTestName(t) + "_ASSIGNER"
 
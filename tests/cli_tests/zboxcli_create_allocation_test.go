@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -426,27 +425,29 @@ func setupWallet(t *test.SystemTest, configPath string) []string {
 	return output
 }
 
-func createNewAllocation(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func createNewAllocation(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	return createNewAllocationForWallet(t, escapedTestName(t), cliConfigFilename, params)
 }
 
-func createNewAllocationForWallet(t *test.SystemTest, wallet, cliConfigFilename, params string) ([]string, error) {
+func createNewAllocationForWallet(t *test.SystemTest, wallet, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Logf("Creating new allocation...")
-	return cliutils.RunCommand(t, fmt.Sprintf(
-		"./zbox newallocation %s --silent --wallet %s --configDir ./config --config %s --allocationFileName %s",
-		params,
-		wallet+"_wallet.json",
-		cliConfigFilename,
-		wallet+"_allocation.txt"), 3, time.Second*5)
+	return cliutils.Zbox("newallocation").
+		WithFlags(params).
+		WithFlag("allocationFileName", wallet+"_allocation.txt").
+		WithSilent().
+		WithWallet(wallet+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*5)
 }
 
-func createNewAllocationWithoutRetry(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
-	return cliutils.RunCommandWithoutRetry(fmt.Sprintf(
-		"./zbox newallocation %s --silent --wallet %s --configDir ./config --config %s --allocationFileName %s",
-		params,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-		escapedTestName(t)+"_allocation.txt"))
+func createNewAllocationWithoutRetry(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
+	return cliutils.Zbox("newallocation").
+		WithFlags(params).
+		WithFlag("allocationFileName", escapedTestName(t)+"_allocation.txt").
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename).
+		RunWithoutRetry(t.Context())
 }
 
 func createAllocationTestTeardown(t *test.SystemTest, allocationID string) {
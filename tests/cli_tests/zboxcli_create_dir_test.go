@@ -306,18 +306,18 @@ func createDir(t *test.SystemTest, cliConfigFilename, allocationID, dirname stri
 }
 
 func createDirForWallet(t *test.SystemTest, cliConfigFilename, wallet string, withAllocationFlag bool, allocationID string, withDirnameFlag bool, dirname string, retry bool) ([]string, error) {
-	cmd := "./zbox createdir --silent --wallet " + wallet + "_wallet.json --configDir ./config --config " + cliConfigFilename
+	cmd := cliutils.Zbox("createdir").WithSilent().WithWallet(wallet + "_wallet.json").WithConfig(cliConfigFilename)
 	if withAllocationFlag {
-		cmd += ` --allocation "` + allocationID + `"`
+		cmd.WithFlag("allocation", allocationID)
 	}
 	if withDirnameFlag {
-		cmd += ` --dirname "` + dirname + `"`
+		cmd.WithFlag("dirname", dirname)
 	}
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -328,12 +328,14 @@ func listAll(t *test.SystemTest, cliConfigFilename, allocationID string, retry b
 func listAllWithWallet(t *test.SystemTest, wallet, cliConfigFilename, allocationID string, retry bool) ([]string, error) {
 	cliutils.Wait(t, 5*time.Second)
 	t.Logf("Listing all...")
-	cmd := "./zbox list-all --silent --allocation " + allocationID +
-		" --wallet " + wallet + "_wallet.json --configDir ./config --config " + cliConfigFilename
-
+	cmd := cliutils.Zbox("list-all").
+		WithFlag("allocation", allocationID).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
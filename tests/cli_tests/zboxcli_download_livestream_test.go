@@ -32,7 +32,7 @@ func TestLivestreamDownload(testSetup *testing.T) { // nolint cyclomatic complex
 	defer KillFFMPEG()
 
 	t.RunSequentiallyWithTimeout("Downloading youtube feed to allocation should work", 400*time.Second, func(t *test.SystemTest) {
-		feed, ok := getFeed()
+		feed, ok := getFeed(t)
 
 		if !ok {
 			t.Skipf("No live feed available right now")
@@ -71,7 +71,7 @@ func TestLivestreamDownload(testSetup *testing.T) { // nolint cyclomatic complex
 		defer os.RemoveAll(localfolderForUpload)
 		defer os.RemoveAll(localfolderForDownload)
 
-		err = startUploadAndDownloadFeed(t, "feed", configPath, localfolderForUpload, localfolderForDownload, createParams(map[string]interface{}{
+		err = startUploadAndDownloadFeed(t, "feed", configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
 			"remotepath": remotepath,
 			"localpath":  localpathForUpload,
@@ -218,7 +218,7 @@ func TestLivestreamDownload(testSetup *testing.T) { // nolint cyclomatic complex
 		defer os.RemoveAll(localfolderForUpload)
 		defer os.RemoveAll(localfolderForDownload)
 
-		err = startUploadAndDownloadFeed(t, "stream", configPath, localfolderForUpload, localfolderForDownload, createParams(map[string]interface{}{
+		err = startUploadAndDownloadFeed(t, "stream", configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
 			"remotepath": remotepath,
 			"localpath":  localpathForUpload,
@@ -332,7 +332,7 @@ func TestLivestreamDownload(testSetup *testing.T) { // nolint cyclomatic complex
 	})
 
 	t.RunSequentiallyWithTimeout("Downloading feed to allocation with delay flag", 3*time.Minute, func(t *test.SystemTest) { // todo this is unacceptably slow
-		feed, ok := getFeed()
+		feed, ok := getFeed(t)
 
 		if !ok {
 			t.Skipf("No live feed available right now")
@@ -372,7 +372,7 @@ func TestLivestreamDownload(testSetup *testing.T) { // nolint cyclomatic complex
 		defer os.RemoveAll(localfolderForUpload)
 		defer os.RemoveAll(localfolderForDownload)
 
-		err = startUploadAndDownloadFeed(t, "feed", configPath, localfolderForUpload, localfolderForDownload, createParams(map[string]interface{}{
+		err = startUploadAndDownloadFeed(t, "feed", configPath, createParams(map[string]interface{}{
 			"allocation": allocationID,
 			"remotepath": remotepath,
 			"localpath":  localpathForUpload,
@@ -485,46 +485,37 @@ func TestLivestreamDownload(testSetup *testing.T) { // nolint cyclomatic complex
 	})
 }
 
-func startUploadAndDownloadFeed(t *test.SystemTest, command, cliConfigFilename, localfolderForUpload, localfolderForDownload, uploadParams, downloadParams string) error {
+func startUploadAndDownloadFeed(t *test.SystemTest, command, cliConfigFilename string, uploadParams, downloadParams cliutils.Flags) error {
 	t.Logf("Starting upload of live stream to zbox...")
-	commandString := fmt.Sprintf("./zbox %s %s --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, command, uploadParams)
-
-	cmd, err := cliutils.StartCommand(t, commandString, 3, 15*time.Second)
+	upload, err := cliutils.Zbox(command).
+		WithFlags(uploadParams).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Start(t, feedProcessOptions("upload feed", uploadParams))
 	require.Nil(t, err, "error in uploading a live feed")
+	defer upload.Stop() //nolint: errcheck
 
-	ready := waitTsFilesReady(t, localfolderForUpload)
-	if !ready {
-		defer cmd.Process.Kill() //nolint: errcheck
-
-		return errors.New("download video files is timeout")
-	}
-	err = startDownloadFeed(t, cliConfigFilename, localfolderForDownload, downloadParams)
+	err = startDownloadFeed(t, cliConfigFilename, downloadParams)
 	require.Nil(t, err, "error in startDownloadFeed")
 
-	err = cmd.Process.Kill()
-	require.Nil(t, err, "error in killing process")
-
 	return nil
 }
 
-func startDownloadFeed(t *test.SystemTest, cliConfigFilename, localFolder, params string) error {
+func startDownloadFeed(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) error {
 	t.Logf("Starting download of live stream from zbox.")
 
-	commandString := fmt.Sprintf("./zbox download --live %s --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, params)
-
-	cmd, err := cliutils.StartCommand(t, commandString, 3, 15*time.Second)
-
-	require.Nil(t, err, "error in downloading the live feed")
-
-	ready := waitTsFilesReady(t, localFolder)
-
-	if !ready {
-		defer cmd.Process.Kill() //nolint: errcheck
-
-		return errors.New("download video files is timeout")
+	download, err := cliutils.Zbox("download").
+		WithFlag("live", nil).
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Start(t, feedProcessOptions("download feed", params))
+	if err != nil {
+		return err
 	}
 
-	err = cmd.Process.Kill()
-
+	_, err = download.Stop()
 	return err
 }
@@ -30,7 +30,7 @@ func TestFileDownloadTokenMovement(testSetup *testing.T) {
 		file := generateRandomTestFileName(t)
 		remoteOwnerPath := "/" + filepath.Base(file)
 		fileSize := int64(10240) // must upload bigger file to ensure has noticeable cost
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -121,34 +121,47 @@ func readPoolInfo(t *test.SystemTest, cliConfigFilename string) ([]string, error
 func readPoolInfoWithWallet(t *test.SystemTest, wallet, cliConfigFilename string) ([]string, error) {
 	cliutils.Wait(t, 30*time.Second) // TODO replace with poller
 	t.Logf("Getting read pool info...")
-	return cliutils.RunCommand(t, "./zbox rp-info"+" --json --silent --wallet "+wallet+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	return cliutils.Zbox("rp-info").
+		WithFlag("json", nil).
+		WithSilent().
+		WithWallet(wallet+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
-func readPoolLock(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func readPoolLock(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return readPoolLockWithWallet(t, escapedTestName(t), cliConfigFilename, params, retry)
 }
 
-func readPoolLockWithWallet(t *test.SystemTest, wallet, cliConfigFilename, params string, retry bool) ([]string, error) {
+func readPoolLockWithWallet(t *test.SystemTest, wallet, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Locking read tokens...")
-	cmd := fmt.Sprintf("./zbox rp-lock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename)
+	cmd := cliutils.Zbox("rp-lock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func getDownloadCost(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func getDownloadCost(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return getDownloadCostWithWallet(t, escapedTestName(t), cliConfigFilename, params, retry)
 }
 
-func getDownloadCostWithWallet(t *test.SystemTest, wallet, cliConfigFilename, params string, retry bool) ([]string, error) {
+func getDownloadCostWithWallet(t *test.SystemTest, wallet, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Getting download cost...")
-	cmd := fmt.Sprintf("./zbox get-download-cost %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename)
+	cmd := cliutils.Zbox("get-download-cost").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
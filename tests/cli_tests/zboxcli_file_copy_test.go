@@ -3,7 +3,10 @@ package cli_tests
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -27,7 +30,7 @@ func TestFileCopy(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -183,7 +186,7 @@ func TestFileCopy(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -255,7 +258,7 @@ func TestFileCopy(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -318,7 +321,7 @@ func TestFileCopy(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -381,11 +384,11 @@ func TestFileCopy(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		existingFileInDest := generateRandomTestFileName(t)
-		err = createFileWithSize(existingFileInDest, fileSize)
+		err = createFileWithSize(t, existingFileInDest, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -495,7 +498,7 @@ func TestFileCopy(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err = createFileWithSize(file, fileSize)
+		err = createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -605,11 +608,11 @@ func TestFileCopy(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		existingFileInDest := generateRandomTestFileName(t)
-		err = createFileWithSize(existingFileInDest, fileSize)
+		err = createFileWithSize(t, existingFileInDest, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -646,6 +649,68 @@ func TestFileCopy(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		require.NotNil(t, err, strings.Join(output, "\n"))
 		require.Contains(t, strings.Join(output, "\n"), "Invalid path record not found")
 	})
+
+	t.RunFlakyWithTimeout("File copy - Users should not be charged for moving a file ", "https://github.com/0chain/zboxcli/issues/334", 60*time.Second, func(t *test.SystemTest) {
+		output, err := registerWallet(t, configPath)
+		require.Nil(t, err, "registering wallet failed", strings.Join(output, "\n"))
+
+		output, err = executeFaucetWithTokens(t, configPath, 2.0)
+		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
+
+		// Lock 0.5 token for allocation
+		allocParams := createParams(map[string]interface{}{
+			"lock": "0.5",
+			"size": 4 * MB,
+		})
+		output, err = createNewAllocation(t, configPath, allocParams)
+		require.Nil(t, err, "Failed to create new allocation", strings.Join(output, "\n"))
+
+		require.Len(t, output, 1)
+		require.Regexp(t, regexp.MustCompile("Allocation created: ([a-f0-9]{64})"), output[0], "Allocation creation output did not match expected")
+		allocationID := strings.Fields(output[0])[2]
+		fileSize := int64(math.Floor(1 * MB))
+
+		// Upload 1 MB file
+		localpath := uploadRandomlyGeneratedFile(t, allocationID, "/", fileSize)
+
+		// Get initial write pool
+		cliutils.Wait(t, 10*time.Second)
+
+		initialAllocation := getAllocation(t, allocationID)
+
+		// Move file
+		remotepath := "/" + filepath.Base(localpath)
+
+		// copy file
+		output, err = copyFile(t, configPath, map[string]interface{}{
+			"allocation": allocationID,
+			"remotepath": remotepath,
+			"destpath":   "/newdir/",
+		}, true)
+		require.Nil(t, err, strings.Join(output, "\n"))
+		require.Len(t, output, 1)
+		require.Equal(t, fmt.Sprintf(remotepath+" copied"), output[0])
+
+		// Get expected upload cost
+		output, _ = getUploadCostInUnit(t, configPath, allocationID, localpath)
+
+		expectedUploadCostInZCN, err := strconv.ParseFloat(strings.Fields(output[0])[0], 64)
+		require.Nil(t, err, "Cost couldn't be parsed to float", strings.Join(output, "\n"))
+
+		unit := strings.Fields(output[0])[1]
+		expectedUploadCostInZCN = unitToZCN(expectedUploadCostInZCN, unit)
+
+		// Expected cost is given in "per 720 hours", we need 1 hour
+		// Expected cost takes into account data+parity, so we divide by that
+		actualExpectedUploadCostInZCN := expectedUploadCostInZCN / ((2 + 2) * 720)
+
+		finalAllocation := getAllocation(t, allocationID)
+
+		actualCost := initialAllocation.WritePool - finalAllocation.WritePool
+		require.True(t, actualCost == 0 || intToZCN(actualCost) == actualExpectedUploadCostInZCN)
+
+		createAllocationTestTeardown(t, allocationID)
+	})
 }
 
 func copyFile(t *test.SystemTest, cliConfigFilename string, param map[string]interface{}, retry bool) ([]string, error) {
@@ -654,17 +719,15 @@ func copyFile(t *test.SystemTest, cliConfigFilename string, param map[string]int
 
 func copyFileForWallet(t *test.SystemTest, cliConfigFilename, wallet string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Copying file...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox copy %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		wallet+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("copy").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*20)
+		return cmd.Run(t, 3, time.Second*20)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
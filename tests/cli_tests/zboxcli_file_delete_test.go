@@ -85,7 +85,7 @@ func TestFileDelete(testSetup *testing.T) {
 		remotepath := "/"
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, 10)
+		err := createFileWithSize(t, filename, 10)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -342,18 +342,16 @@ func TestFileDelete(testSetup *testing.T) {
 	})
 }
 
-func deleteFile(t *test.SystemTest, walletName, params string, retry bool) ([]string, error) {
+func deleteFile(t *test.SystemTest, walletName string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Deleting file...")
-	cmd := fmt.Sprintf(
-		"./zbox delete %s --silent --wallet %s "+
-			"--configDir ./config --config %s",
-		params,
-		walletName+"_wallet.json",
-		configPath,
-	)
+	cmd := cliutils.Zbox("delete").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*20)
+		return cmd.Run(t, 3, time.Second*20)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
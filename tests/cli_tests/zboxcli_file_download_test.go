@@ -338,7 +338,7 @@ func TestDownload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, filesize)
+		err := createFileWithSize(t, filename, filesize)
 		require.Nil(t, err)
 		originalFileChecksum := generateChecksum(t, filename)
 
@@ -1182,7 +1182,7 @@ func TestDownload(testSetup *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, strings.Join(output, "\n"))
 
-		output, err = downloadFile(t, configPath, "", false)
+		output, err = downloadFile(t, configPath, nil, false)
 		require.NotNil(t, err, strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
@@ -1313,31 +1313,30 @@ func setupAllocationAndReadLock(t *test.SystemTest, cliConfigFilename string, ex
 	return allocationID
 }
 
-func downloadFile(t *test.SystemTest, cliConfigFilename, param string, retry bool) ([]string, error) {
+func downloadFile(t *test.SystemTest, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	return downloadFileForWallet(t, escapedTestName(t), cliConfigFilename, param, retry)
 }
 
-func downloadFileForWallet(t *test.SystemTest, wallet, cliConfigFilename, param string, retry bool) ([]string, error) {
+func downloadFileForWallet(t *test.SystemTest, wallet, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	cliutils.Wait(t, 15*time.Second) // TODO replace with pollers
 	t.Logf("Downloading file...")
-	cmd := fmt.Sprintf(
-		"./zbox download %s --silent --wallet %s --configDir ./config --config %s",
-		param,
-		wallet+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("download").
+		WithFlags(param).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func generateChecksum(t *test.SystemTest, filePath string) string {
 	t.Logf("Generating checksum for file [%v]...", filePath)
 
-	output, err := cliutils.RunCommandWithoutRetry("shasum -a 256 " + filePath)
+	output, err := cliutils.RunCommandWithoutRetryContext(t.Context(), "shasum -a 256 "+filePath)
 	require.Nil(t, err, "Checksum generation for file %v failed", filePath, strings.Join(output, "\n"))
 	require.Greater(t, len(output), 0)
 
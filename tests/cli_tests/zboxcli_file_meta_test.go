@@ -224,7 +224,7 @@ func TestFileMetadata(testSetup *testing.T) {
 		filename := generateRandomTestFileName(t)
 		fname := filepath.Base(filename)
 
-		err := createFileWithSize(filename, filesize)
+		err := createFileWithSize(t, filename, filesize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -370,7 +370,7 @@ func TestFileMetadata(testSetup *testing.T) {
 	})
 
 	t.Run("Get File Meta Without Parameter Should Fail", func(t *test.SystemTest) {
-		output, err := getFileMeta(t, configPath, "", false)
+		output, err := getFileMeta(t, configPath, nil, false)
 		require.NotNil(t, err, strings.Join(output, "\n"))
 		require.Greater(t, len(output), 0)
 
@@ -381,22 +381,21 @@ func TestFileMetadata(testSetup *testing.T) {
 	})
 }
 
-func getFileMeta(t *test.SystemTest, cliConfigFilename, param string, retry bool) ([]string, error) {
+func getFileMeta(t *test.SystemTest, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	return getFileMetaWithWallet(t, escapedTestName(t), cliConfigFilename, param, retry)
 }
 
-func getFileMetaWithWallet(t *test.SystemTest, walletName, cliConfigFilename, param string, retry bool) ([]string, error) {
+func getFileMetaWithWallet(t *test.SystemTest, walletName, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	cliutils.Wait(t, 5*time.Second)
 	t.Logf("Getting file metadata...")
-	cmd := fmt.Sprintf(
-		"./zbox meta %s --silent --wallet %s --configDir ./config --config %s",
-		param,
-		walletName+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("meta").
+		WithFlags(param).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -30,7 +30,7 @@ func TestFileMove(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -182,7 +182,7 @@ func TestFileMove(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -307,7 +307,7 @@ func TestFileMove(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -370,11 +370,11 @@ func TestFileMove(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		existingFileInDest := generateRandomTestFileName(t)
-		err = createFileWithSize(existingFileInDest, fileSize)
+		err = createFileWithSize(t, existingFileInDest, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -485,7 +485,7 @@ func TestFileMove(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err = createFileWithSize(file, fileSize)
+		err = createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -653,11 +653,11 @@ func TestFileMove(testSetup *testing.T) { // nolint:gocyclo // team preference i
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		existingFileInDest := generateRandomTestFileName(t)
-		err = createFileWithSize(existingFileInDest, fileSize)
+		err = createFileWithSize(t, existingFileInDest, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -707,17 +707,14 @@ func moveFile(t *test.SystemTest, cliConfigFilename string, param map[string]int
 
 func moveFileWithWallet(t *test.SystemTest, wallet, cliConfigFilename string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Moving file...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox move %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		wallet+"_wallet.json",
-		cliConfigFilename,
-	)
-
+	cmd := cliutils.Zbox("move").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*20)
+		return cmd.Run(t, 3, time.Second*20)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
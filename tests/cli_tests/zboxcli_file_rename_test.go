@@ -29,7 +29,7 @@ func TestFileRename(testSetup *testing.T) { // nolint:gocyclo // team preference
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -167,7 +167,7 @@ func TestFileRename(testSetup *testing.T) { // nolint:gocyclo // team preference
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -229,7 +229,7 @@ func TestFileRename(testSetup *testing.T) { // nolint:gocyclo // team preference
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -304,7 +304,7 @@ func TestFileRename(testSetup *testing.T) { // nolint:gocyclo // team preference
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -378,7 +378,7 @@ func TestFileRename(testSetup *testing.T) { // nolint:gocyclo // team preference
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -540,7 +540,7 @@ func TestFileRename(testSetup *testing.T) { // nolint:gocyclo // team preference
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err = createFileWithSize(file, fileSize)
+		err = createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -702,7 +702,7 @@ func TestFileRename(testSetup *testing.T) { // nolint:gocyclo // team preference
 		fileSize := int64(256)
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -743,13 +743,10 @@ func TestFileRename(testSetup *testing.T) { // nolint:gocyclo // team preference
 
 func renameFileWithWallet(t *test.SystemTest, cliConfigFilename, wallet string, param map[string]interface{}) ([]string, error) {
 	t.Logf("Renaming file...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox rename %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		wallet+"_wallet.json",
-		cliConfigFilename,
-	)
-
-	return cliutils.RunCommand(t, cmd, 3, time.Second*20)
+	cmd := cliutils.Zbox("rename").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
+	return cmd.Run(t, 3, time.Second*20)
 }
@@ -486,17 +486,16 @@ func TestFileStats(testSetup *testing.T) {
 	})
 }
 
-func getFileStats(t *test.SystemTest, cliConfigFilename, param string, retry bool) ([]string, error) {
+func getFileStats(t *test.SystemTest, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Getting file stats...")
-	cmd := fmt.Sprintf(
-		"./zbox stats %s --silent --wallet %s --configDir ./config --config %s",
-		param,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("stats").
+		WithFlags(param).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
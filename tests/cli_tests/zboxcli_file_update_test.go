@@ -244,7 +244,7 @@ func TestFileUpdate(testSetup *testing.T) {
 		localFilePath := generateFileAndUpload(t, allocationID, remotepath, filesize)
 
 		localfile := generateRandomTestFileName(t)
-		err := createFileWithSize(localfile, int64(filesize))
+		err := createFileWithSize(t, localfile, int64(filesize))
 		require.Nil(t, err)
 
 		params := createParams(map[string]interface{}{"allocation": allocationID, "remotepath": "/"})
@@ -288,7 +288,7 @@ func TestFileUpdate(testSetup *testing.T) {
 		localFilePath := generateFileAndUploadWithParam(t, allocationID, remotepath, filesize, map[string]interface{}{"encrypt": true})
 
 		localfile := generateRandomTestFileName(t)
-		err := createFileWithSize(localfile, int64(filesize))
+		err := createFileWithSize(t, localfile, int64(filesize))
 		require.Nil(t, err)
 
 		params := createParams(map[string]interface{}{"allocation": allocationID, "remotepath": "/"})
@@ -341,7 +341,7 @@ func TestFileUpdate(testSetup *testing.T) {
 		require.Equal(t, filepath.Base(localFilePath), strings.TrimSpace(filename))
 
 		localfile := generateRandomTestFileName(t)
-		err = createFileWithSize(localfile, int64(filesize))
+		err = createFileWithSize(t, localfile, int64(filesize))
 		require.Nil(t, err)
 
 		// update with encrypted file
@@ -373,7 +373,7 @@ func TestFileUpdate(testSetup *testing.T) {
 
 		filesize := int64(0.5 * MB)
 		localfile := generateRandomTestFileName(t)
-		err := createFileWithSize(localfile, filesize)
+		err := createFileWithSize(t, localfile, filesize)
 		require.Nil(t, err)
 
 		output, err := updateFile(t, configPath, map[string]interface{}{
@@ -398,7 +398,7 @@ func TestFileUpdate(testSetup *testing.T) {
 
 		newFileSize := 2 * MB
 		localfile := generateRandomTestFileName(t)
-		err := createFileWithSize(localfile, int64(newFileSize))
+		err := createFileWithSize(t, localfile, int64(newFileSize))
 		require.Nil(t, err)
 
 		output, err := updateFile(t, configPath, map[string]interface{}{
@@ -423,7 +423,7 @@ func TestFileUpdate(testSetup *testing.T) {
 
 		newFileSize := 2 * MB
 		localfile := generateRandomTestFileName(t)
-		err := createFileWithSize(localfile, int64(newFileSize))
+		err := createFileWithSize(t, localfile, int64(newFileSize))
 		require.Nil(t, err)
 
 		output, err := updateFile(t, configPath, map[string]interface{}{
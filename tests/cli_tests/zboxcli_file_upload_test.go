@@ -38,7 +38,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -68,7 +68,7 @@ func TestUpload(testSetup *testing.T) {
 
 		for i := 0; i < 2; i++ {
 			filename := generateRandomTestFileName(t)
-			err := createFileWithSize(filename, fileSize)
+			err := createFileWithSize(t, filename, fileSize)
 			require.Nil(t, err)
 
 			output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -96,7 +96,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -136,7 +136,7 @@ func TestUpload(testSetup *testing.T) {
 				defer wg.Done()
 
 				fileName := generateRandomTestFileName(t)
-				err := createFileWithSize(fileName, fileSize)
+				err := createFileWithSize(t, fileName, fileSize)
 				require.Nil(t, err)
 
 				fileNameBase := filepath.Base(fileName)
@@ -173,7 +173,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -200,7 +200,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -245,7 +245,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -274,7 +274,7 @@ func TestUpload(testSetup *testing.T) {
 		thumbnail := escapedTestName(t) + "thumbnail.png"
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -355,7 +355,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -381,7 +381,7 @@ func TestUpload(testSetup *testing.T) {
 
 		filename := generateRandomTestFileName(t)
 
-		err := createFileWithSize(filename, 10)
+		err := createFileWithSize(t, filename, 10)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -411,7 +411,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -438,7 +438,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -461,7 +461,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -484,7 +484,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -506,7 +506,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -540,7 +540,7 @@ func TestUpload(testSetup *testing.T) {
 		require.Nil(t, err)
 
 		filename := generateRandomTestFileName(t)
-		err = createFileWithSize(filename, fileSize)
+		err = createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFileWithoutRetry(t, configPath, map[string]interface{}{
@@ -567,7 +567,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		t.Run("Get Other Allocation ID", func(t *test.SystemTest) {
@@ -635,7 +635,7 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFileWithoutRetry(t, configPath, map[string]interface{}{
@@ -687,9 +687,9 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		dirPath := strings.TrimSuffix(os.TempDir(), string(os.PathSeparator))
-		randomFilename := cliutils.RandomAlphaNumericString(101)
+		randomFilename := cliutils.RandomAlphaNumericString(t, 101)
 		filename := fmt.Sprintf("%s%s%s_test.txt", dirPath, string(os.PathSeparator), randomFilename)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -712,9 +712,9 @@ func TestUpload(testSetup *testing.T) {
 		})
 
 		dirPath := strings.TrimSuffix(os.TempDir(), string(os.PathSeparator))
-		randomFilename := cliutils.RandomAlphaNumericString(101)
+		randomFilename := cliutils.RandomAlphaNumericString(t, 101)
 		filename := fmt.Sprintf("%s%s%s_test.txt", dirPath, string(os.PathSeparator), randomFilename)
-		err := createFileWithSize(filename, fileSize)
+		err := createFileWithSize(t, filename, fileSize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -759,32 +759,27 @@ func uploadFile(t *test.SystemTest, cliConfigFilename string, param map[string]i
 func uploadFileForWallet(t *test.SystemTest, wallet, cliConfigFilename string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Uploading file...")
 
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox upload %s --silent --wallet %s_wallet.json --configDir ./config --config %s",
-		p,
-		wallet,
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("upload").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*40)
+		return cmd.Run(t, 3, time.Second*40)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func uploadFileWithoutRetry(t *test.SystemTest, cliConfigFilename string, param map[string]interface{}) ([]string, error) {
 	t.Logf("Uploading file...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox upload %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-	)
-
-	return cliutils.RunCommandWithoutRetry(cmd)
+	cmd := cliutils.Zbox("upload").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
+	return cmd.RunWithoutRetry(t.Context())
 }
 
 func generateFileAndUpload(t *test.SystemTest, allocationID, remotepath string, size int64) string {
@@ -794,7 +789,7 @@ func generateFileAndUpload(t *test.SystemTest, allocationID, remotepath string,
 func generateFileAndUploadForWallet(t *test.SystemTest, wallet, allocationID, remotepath string, size int64) string {
 	filename := generateRandomTestFileName(t)
 
-	err := createFileWithSize(filename, size)
+	err := createFileWithSize(t, filename, size)
 	require.Nil(t, err)
 
 	// Upload parameters
@@ -810,7 +805,7 @@ func generateFileAndUploadForWallet(t *test.SystemTest, wallet, allocationID, re
 func generateFileAndUploadWithParam(t *test.SystemTest, allocationID, remotepath string, size int64, params map[string]interface{}) string {
 	filename := generateRandomTestFileName(t)
 
-	err := createFileWithSize(filename, size)
+	err := createFileWithSize(t, filename, size)
 	require.Nil(t, err)
 
 	p := map[string]interface{}{
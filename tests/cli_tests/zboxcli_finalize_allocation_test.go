@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -65,14 +64,11 @@ func TestFinalizeAllocation(testSetup *testing.T) {
 	})
 
 	t.Run("No allocation param should fail", func(t *test.SystemTest) {
-		cmd := fmt.Sprintf(
-			"./zbox alloc-fini --silent "+
-				"--wallet %s --configDir ./config --config %s",
-			escapedTestName(t)+"_wallet.json",
-			configPath,
-		)
-
-		output, err := cliutils.RunCommandWithoutRetry(cmd)
+		output, err := cliutils.Zbox("alloc-fini").
+			WithSilent().
+			WithWallet(escapedTestName(t) + "_wallet.json").
+			WithConfig(configPath).
+			RunWithoutRetry(t.Context())
 		require.Error(t, err, "expected error finalizing allocation", strings.Join(output, "\n"))
 		require.Len(t, output, 4)
 		require.Equal(t, "Error: allocation flag is missing", output[len(output)-1])
@@ -81,16 +77,14 @@ func TestFinalizeAllocation(testSetup *testing.T) {
 
 func finalizeAllocation(t *test.SystemTest, cliConfigFilename, allocationID string, retry bool) ([]string, error) {
 	t.Logf("Finalizing allocation...")
-	cmd := fmt.Sprintf(
-		"./zbox alloc-fini --allocation %s "+
-			"--silent --wallet %s --configDir ./config --config %s",
-		allocationID,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("alloc-fini").
+		WithFlag("allocation", allocationID).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -108,24 +107,23 @@ func TestKillBlobber(testSetup *testing.T) {
 	})
 }
 
-func killBlobber(t *test.SystemTest, wallet, cliConfigFilename, params string, retry bool) ([]string, error) {
+func killBlobber(t *test.SystemTest, wallet, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("kill blobber...")
-	cmd := fmt.Sprintf("./zbox kill-blobber %s --silent --wallet %s_wallet.json --configDir ./config --config %s",
-		params, wallet, cliConfigFilename)
+	cmd := cliutils.Zbox("kill-blobber").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func getBlobbers(t *test.SystemTest) []model.BlobberDetails {
-	var blobbers []model.BlobberDetails
-	output, err := listBlobbers(t, configPath, "--json")
-	require.NoError(t, err, "Error listing blobbers", strings.Join(output, "\n"))
-	require.True(t, len(output) > 0, "no output to ls-blobbers")
-	err = json.Unmarshal([]byte(output[len(output)-1]), &blobbers)
-	require.NoError(t, err, "Error unmarshalling blobber list", strings.Join(output, "\n"))
+	blobbers, err := zboxClient(t).ListBlobbers(t)
+	require.NoError(t, err, "Error listing blobbers")
 	require.True(t, len(blobbers) > 0, "No blobbers found in blobber list")
 	return blobbers
 }
@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"math/rand"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -89,7 +88,7 @@ func TestListFileSystem(testSetup *testing.T) {
 		filename := generateRandomTestFileName(t)
 		fname := filepath.Base(filename)
 
-		err := createFileWithSize(filename, filesize)
+		err := createFileWithSize(t, filename, filesize)
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -496,7 +495,7 @@ func TestListFileSystem(testSetup *testing.T) {
 	})
 
 	t.Run("No Parameter Should Fail", func(t *test.SystemTest) {
-		output, err := listFilesInAllocation(t, configPath, "", false)
+		output, err := listFilesInAllocation(t, configPath, nil, false)
 		require.NotNil(t, err,
 			"List files with no parameter failed due to error", err,
 			strings.Join(output, "\n"))
@@ -594,9 +593,9 @@ func extractAuthToken(str string) (string, error) {
 	return "", errors.New("auth token did not match")
 }
 
-func createFileWithSize(name string, size int64) error {
+func createFileWithSize(t *test.SystemTest, name string, size int64) error {
 	buffer := make([]byte, size)
-	rand.Read(buffer) //nolint:gosec,revive
+	t.Rand().Read(buffer) //nolint:gosec,revive
 	return os.WriteFile(name, buffer, os.ModePerm)
 }
 
@@ -604,57 +603,54 @@ func generateRandomTestFileName(t *test.SystemTest) string {
 	path := strings.TrimSuffix(os.TempDir(), string(os.PathSeparator))
 
 	//FIXME: Filenames longer than 100 characters are rejected see https://github.com/0chain/zboxcli/issues/249
-	randomFilename := cliutils.RandomAlphaNumericString(10)
+	randomFilename := cliutils.RandomAlphaNumericString(t, 10)
 	return fmt.Sprintf("%s%s%s_test.txt", path, string(os.PathSeparator), randomFilename)
 }
 
-func shareFolderInAllocation(t *test.SystemTest, cliConfigFilename, param string) ([]string, error) {
+func shareFolderInAllocation(t *test.SystemTest, cliConfigFilename string, param cliutils.Flags) ([]string, error) {
 	return shareFolderInAllocationForWallet(t, escapedTestName(t), cliConfigFilename, param)
 }
 
-func shareFolderInAllocationForWallet(t *test.SystemTest, wallet, cliConfigFilename, param string) ([]string, error) {
+func shareFolderInAllocationForWallet(t *test.SystemTest, wallet, cliConfigFilename string, param cliutils.Flags) ([]string, error) {
 	t.Logf("Sharing file/folder...")
-	cmd := fmt.Sprintf(
-		"./zbox share %s --silent --wallet %s --configDir ./config --config %s",
-		param,
-		wallet+"_wallet.json",
-		cliConfigFilename,
-	)
-	return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+	return cliutils.Zbox("share").
+		WithFlags(param).
+		WithSilent().
+		WithWallet(wallet+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
-func listFilesInAllocation(t *test.SystemTest, cliConfigFilename, param string, retry bool) ([]string, error) {
+func listFilesInAllocation(t *test.SystemTest, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	return listFilesInAllocationForWallet(t, escapedTestName(t), cliConfigFilename, param, retry)
 }
 
-func listFilesInAllocationForWallet(t *test.SystemTest, wallet, cliConfigFilename, param string, retry bool) ([]string, error) {
+func listFilesInAllocationForWallet(t *test.SystemTest, wallet, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	cliutils.Wait(t, 10*time.Second) // TODO replace with poller
 	t.Logf("Listing individual file in allocation...")
-	cmd := fmt.Sprintf(
-		"./zbox list %s --silent --wallet %s --configDir ./config --config %s",
-		param,
-		wallet+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("list").
+		WithFlags(param).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func listAllFilesInAllocation(t *test.SystemTest, cliConfigFilename, param string, retry bool) ([]string, error) {
+func listAllFilesInAllocation(t *test.SystemTest, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	cliutils.Wait(t, 10*time.Second) // TODO replace with poller
 	t.Logf("Listing all files in allocation...")
-	cmd := fmt.Sprintf(
-		"./zbox list-all %s --silent --wallet %s --configDir ./config --config %s",
-		param,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("list-all").
+		WithFlags(param).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -1,12 +1,9 @@
 package cli_tests
 
 import (
-	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io/fs"
-	"math/rand"
 	"net/http"
 	"os"
 	"os/exec"
@@ -19,6 +16,7 @@ import (
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
@@ -34,7 +32,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 
 	// Success scenarios
 	t.RunSequentiallyWithTimeout("Uploading remote feed to allocation should work", 2*time.Minute, func(t *test.SystemTest) { // todo slow
-		feed, ok := getFeed()
+		feed, ok := getFeed(t)
 
 		if !ok {
 			t.Skipf("No live feed available right now")
@@ -62,7 +60,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 		require.Nil(t, err, "Error in creating the folders", localpath)
 		defer os.RemoveAll(localfolder)
 
-		err = startUploadFeed(t, configPath, "feed", localfolder, createParams(map[string]interface{}{
+		err = startUploadFeed(t, configPath, "feed", createParams(map[string]interface{}{
 			"allocation": allocationID,
 			"remotepath": remotepath,
 			"localpath":  localpath,
@@ -108,7 +106,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 	})
 
 	t.RunSequentiallyWithTimeout("Upload from feed with delay flag must work", 4*time.Minute, func(t *test.SystemTest) { // todo slow
-		feed, ok := getFeed()
+		feed, ok := getFeed(t)
 
 		if !ok {
 			t.Skipf("No live feed available right now")
@@ -136,7 +134,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 		require.Nil(t, err, "Error in creating the folders", localpath)
 		defer os.RemoveAll(localfolder)
 
-		err = startUploadFeed(t, configPath, "feed", localfolder, createParams(map[string]interface{}{
+		err = startUploadFeed(t, configPath, "feed", createParams(map[string]interface{}{
 			"allocation": allocationID,
 			"remotepath": remotepath,
 			"localpath":  localpath,
@@ -182,7 +180,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 	})
 
 	t.RunSequentiallyWithTimeout("Upload from feed with a different chunknumber must work", 2*time.Minute, func(t *test.SystemTest) {
-		feed, ok := getFeed()
+		feed, ok := getFeed(t)
 
 		if !ok {
 			t.Skipf("No live feed available right now")
@@ -210,7 +208,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 		require.Nil(t, err, "Error in creating the folders", localpath)
 		defer os.RemoveAll(localfolder)
 
-		err = startUploadFeed(t, configPath, "feed", localfolder, createParams(map[string]interface{}{
+		err = startUploadFeed(t, configPath, "feed", createParams(map[string]interface{}{
 			"allocation":  allocationID,
 			"remotepath":  remotepath,
 			"localpath":   localpath,
@@ -282,7 +280,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 		require.Nil(t, err, "Error in creating the folders", localpath)
 		defer os.RemoveAll(localfolder)
 
-		err = startUploadFeed(t, configPath, "stream", localfolder, createParams(map[string]interface{}{
+		err = startUploadFeed(t, configPath, "stream", createParams(map[string]interface{}{
 			"allocation": allocationID,
 			"remotepath": remotepath,
 			"localpath":  localpath,
@@ -350,7 +348,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 		require.Nil(t, err, "Error in creating the folders", localpath)
 		defer os.RemoveAll(localfolder)
 
-		err = startUploadFeed(t, configPath, "stream", localfolder, createParams(map[string]interface{}{
+		err = startUploadFeed(t, configPath, "stream", createParams(map[string]interface{}{
 			"allocation": allocationID,
 			"remotepath": remotepath,
 			"localpath":  localpath,
@@ -419,7 +417,7 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 		require.Nil(t, err, "Error in creating the folders", localpath)
 		defer os.RemoveAll(localfolder)
 
-		err = startUploadFeed(t, configPath, "stream", localfolder, createParams(map[string]interface{}{
+		err = startUploadFeed(t, configPath, "stream", createParams(map[string]interface{}{
 			"allocation":  allocationID,
 			"remotepath":  remotepath,
 			"localpath":   localpath,
@@ -469,48 +467,79 @@ func TestStreamUploadDownload(testSetup *testing.T) {
 	// FIXME: Disabled for now due to process hanging
 }
 
-func startUploadFeed(t *test.SystemTest, cliConfigFilename, cmdName, localFolder, params string) error {
+func startUploadFeed(t *test.SystemTest, cliConfigFilename, cmdName string, params cliutils.Flags) error {
 	t.Logf("Starting upload of live stream to zbox...")
-	commandString := fmt.Sprintf("./zbox %s %s --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, cmdName, params)
-	cmd, err := cliutils.StartCommand(t, commandString, 3, 15*time.Second)
-	require.Nil(t, err, fmt.Sprintf("error in uploading a live feed: %s", err))
-
-	ready := waitTsFilesReady(t, localFolder)
-	if !ready {
-		defer cmd.Process.Kill() //nolint: errcheck
-
-		return errors.New("download video files is timeout")
+	feed, err := cliutils.Zbox(cmdName).
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Start(t, feedProcessOptions("upload feed", params))
+	if err != nil {
+		return err
 	}
+	defer feed.Stop() //nolint: errcheck
 
-	// waiting for .ts files to upload
-	cliutils.Wait(t, 30*time.Second)
-	cmd.Process.Kill() //nolint: errcheck
-	return nil
+	// keep the feed running until its first segments are uploaded, as the test cases list them afterwards
+	return waitForUploadedSegments(t, cliConfigFilename, params)
 }
 
-func waitTsFilesReady(t *test.SystemTest, localFolder string) bool {
-	// Need atleast 3-4 .ts files downloaded and generated by youtube-dl and ffmpeg
-	ctx, cancel := context.WithTimeout(context.TODO(), 3*time.Minute)
-	defer cancel()
+// waitForUploadedSegments polls the remote path of the feed until it lists an uploaded .ts segment
+func waitForUploadedSegments(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) error {
+	return wait.Eventually(t.Context(), time.Minute, wait.Constant(time.Second*5), func() error {
+		output, err := cliutils.Zbox("list").
+			WithFlags(cliutils.Flags{"allocation": params["allocation"], "remotepath": params["remotepath"]}).
+			WithFlag("json", nil).
+			WithSilent().
+			WithWallet(escapedTestName(t) + "_wallet.json").
+			WithConfig(cliConfigFilename).
+			RunWithoutRetry(t.Context())
+		if err != nil {
+			return fmt.Errorf("listing uploaded segments failed: %w", err)
+		}
+		if len(output) != 1 {
+			return fmt.Errorf("unexpected list output: %s", strings.Join(output, "\n"))
+		}
 
-	for {
-		select {
-		case <-ctx.Done():
-			return false
-		case <-time.After(1 * time.Second):
-			files, _ := os.ReadDir(localFolder)
-			c := 0
-			for _, file := range files {
-				if strings.HasSuffix(file.Name(), ".ts") {
-					c++
-				}
+		var files []climodel.ListFileResult
+		if err := json.Unmarshal([]byte(output[0]), &files); err != nil {
+			return fmt.Errorf("unmarshalling list output failed: %w", err)
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file.Name, ".ts") {
+				return nil
 			}
+		}
+		return fmt.Errorf("no .ts segment uploaded yet: %s", output[0])
+	})
+}
 
-			if c > 2 {
-				return true
-			}
+// feedMaxLifetime bounds how long a live stream upload or download may run, in case a test case fails to stop it
+const feedMaxLifetime = 10 * time.Minute
+
+// feedProcessOptions treats a feed as ready once at least 3 .ts segments were written next to its local path,
+// by youtube-dl and ffmpeg for an upload or by zbox for a download
+func feedProcessOptions(name string, params cliutils.Flags) cliutils.ProcessOptions {
+	localFolder := filepath.Dir(fmt.Sprint(params["localpath"]))
+	return cliutils.ProcessOptions{
+		Name: name,
+		ReadyCheck: func() bool {
+			return countTsFiles(localFolder) > 2
+		},
+		ReadyTimeout: 3 * time.Minute,
+		MaxLifetime:  feedMaxLifetime,
+	}
+}
+
+func countTsFiles(localFolder string) int {
+	files, _ := os.ReadDir(localFolder)
+	c := 0
+	for _, file := range files {
+		if strings.HasSuffix(file.Name(), ".ts") {
+			c++
 		}
 	}
+	return c
 }
 
 var feedMutex sync.Mutex
@@ -526,12 +555,12 @@ var feeds = []string{
 	"https://odysee.com/@fireship:6/how-to-never-write-bug:4",
 }
 
-func getFeed() (string, bool) {
+func getFeed(t *test.SystemTest) (string, bool) {
 	feedMutex.Lock()
 	defer feedMutex.Unlock()
 	n := len(feeds)
 
-	i := rand.Intn(n) //nolint
+	i := t.Rand().Intn(n)
 	var m int
 	for {
 		if m >= n {
@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -38,7 +37,7 @@ func TestRecentlyAddedRefs(testSetup *testing.T) {
 		fPath := generateRandomTestFileName(t)
 		fileName := filepath.Base(fPath)
 		remotePath := filepath.Join(p, fileName)
-		err := createFileWithSize(fPath, fileSize)
+		err := createFileWithSize(t, fPath, fileSize)
 		require.Nil(t, err)
 
 		time.Sleep(time.Second * 30)
@@ -94,7 +93,7 @@ func TestRecentlyAddedRefs(testSetup *testing.T) {
 		fPath := generateRandomTestFileName(t)
 		fileName := filepath.Base(fPath)
 		remotePath := filepath.Join(p, fileName)
-		err := createFileWithSize(fPath, fileSize)
+		err := createFileWithSize(t, fPath, fileSize)
 		require.Nil(t, err)
 		output, err := uploadFile(t, configPath, map[string]interface{}{
 			"allocation": allocationID,
@@ -137,7 +136,7 @@ func TestRecentlyAddedRefs(testSetup *testing.T) {
 		fPath := generateRandomTestFileName(t)
 		fileName := filepath.Base(fPath)
 		remotePath := filepath.Join(p, fileName)
-		err := createFileWithSize(fPath, fileSize)
+		err := createFileWithSize(t, fPath, fileSize)
 		require.Nil(t, err)
 		output, err := uploadFile(t, configPath, map[string]interface{}{
 			"allocation": allocationID,
@@ -203,21 +202,20 @@ func TestRecentlyAddedRefs(testSetup *testing.T) {
 	})
 }
 
-func listRecentlyAddedRefs(t *test.SystemTest, cliConfigFilename, param string, retry bool) ([]string, error) {
+func listRecentlyAddedRefs(t *test.SystemTest, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	return listRecentlyAddedRefsForWallet(t, escapedTestName(t), cliConfigFilename, param, retry)
 }
 
-func listRecentlyAddedRefsForWallet(t *test.SystemTest, wallet, cliConfigFilename, param string, retry bool) ([]string, error) {
+func listRecentlyAddedRefsForWallet(t *test.SystemTest, wallet, cliConfigFilename string, param cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("Listing recently added refs")
-	cmd := fmt.Sprintf(
-		"./zbox recent-refs %s --silent --wallet %s --configDir ./config --config %s ",
-		param,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("recent-refs").
+		WithFlags(param).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	}
-	return cliutils.RunCommandWithoutRetry(cmd)
+	return cmd.RunWithoutRetry(t.Context())
 }
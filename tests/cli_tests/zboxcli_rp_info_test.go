@@ -78,14 +78,19 @@ func TestReadPoolInfo(testSetup *testing.T) {
 		require.Len(t, output, 1)
 		require.Equal(t, "locked", output[0])
 
-		output, err = readPoolInfoWithParams(t, configPath, "")
+		output, err = readPoolInfoWithParams(t, configPath, nil)
 		require.Nil(t, err, "Error fetching read pool info", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		require.Regexp(t, regexp.MustCompile(`Read pool Balance: 1.00\d ZCN \(\d*\.?\d+ USD\)$`), output[0])
 	})
 }
-func readPoolInfoWithParams(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func readPoolInfoWithParams(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	cliutils.Wait(t, 30*time.Second) // TODO replace with poller
 	t.Logf("Getting read pool info...")
-	return cliutils.RunCommand(t, "./zbox rp-info "+params+" --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	return cliutils.Zbox("rp-info").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
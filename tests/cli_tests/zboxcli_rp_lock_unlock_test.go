@@ -1,8 +1,6 @@
 package cli_tests
 
 import (
-	"encoding/json"
-	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -54,7 +52,7 @@ func TestReadPoolLockUnlock(testSetup *testing.T) {
 		readPool := getReadPoolInfo(t)
 		require.Equal(t, ConvertToValue(lockAmount), readPool.Balance, "Read Pool balance must be equal to locked amount")
 
-		output, err = readPoolUnlock(t, configPath, "", true)
+		output, err = readPoolUnlock(t, configPath, nil, true)
 		require.Nil(t, err, "Unable to unlock tokens", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
@@ -181,23 +179,23 @@ func TestReadPoolLockUnlock(testSetup *testing.T) {
 	})
 }
 
-func readPoolUnlock(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func readPoolUnlock(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Unlocking read tokens...")
-	cmd := fmt.Sprintf("./zbox rp-unlock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename)
+	cmd := cliutils.Zbox("rp-unlock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func getReadPoolInfo(t *test.SystemTest) climodel.ReadPoolInfo {
-	output, err := readPoolInfo(t, configPath)
-	require.Nil(t, err, "Error fetching read pool", strings.Join(output, "\n"))
-	require.Len(t, output, 1)
-
-	var readPool climodel.ReadPoolInfo
-	err = json.Unmarshal([]byte(output[0]), &readPool)
-	require.Nil(t, err, "Error unmarshalling read pool %s", strings.Join(output, "\n"))
+	cliutils.Wait(t, 30*time.Second) // TODO replace with poller
+	readPool, err := zboxClient(t).ReadPoolInfo(t)
+	require.Nil(t, err, "Error fetching read pool")
 	return readPool
 }
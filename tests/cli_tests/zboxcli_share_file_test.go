@@ -3,7 +3,6 @@ package cli_tests
 import (
 	"encoding/hex"
 	"encoding/json"
-	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -33,7 +32,7 @@ func TestShareFile(testSetup *testing.T) {
 		file := generateRandomTestFileName(t)
 		remoteOwnerPath := "/subfolder1/subfolder2/" + filepath.Base(file)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -87,7 +86,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -140,7 +139,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -192,7 +191,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -229,7 +228,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -292,7 +291,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -348,7 +347,7 @@ func TestShareFile(testSetup *testing.T) {
 		file := generateRandomTestFileName(t)
 		remoteOwnerPath := "/subfolder1/subfolder2/" + filepath.Base(file)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -401,7 +400,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -462,7 +461,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(102400) // this is big enough to cause problem with download
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -540,7 +539,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -617,7 +616,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -682,7 +681,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -744,7 +743,7 @@ func TestShareFile(testSetup *testing.T) {
 		// upload file
 		file := generateRandomTestFileName(t)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -808,7 +807,7 @@ func TestShareFile(testSetup *testing.T) {
 		file := generateRandomTestFileName(t)
 		remoteOwnerPath := "/subfolder1/subfolder2/" + filepath.Base(file)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -870,7 +869,7 @@ func TestShareFile(testSetup *testing.T) {
 		file := generateRandomTestFileName(t)
 		remoteOwnerPath := "/subfolder1/subfolder2/" + filepath.Base(file)
 		fileSize := int64(256)
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -1040,7 +1039,7 @@ func TestShareFile(testSetup *testing.T) {
 		file := generateRandomTestFileName(t)
 		remoteOwnerPath := "/" + filepath.Base(file)
 		fileSize := int64(10240) // must upload bigger file to ensure has noticeable cost
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -1156,7 +1155,7 @@ func TestShareFile(testSetup *testing.T) {
 		file := generateRandomTestFileName(t)
 		remoteOwnerPath := "/" + filepath.Base(file)
 		fileSize := int64(10240) // must upload bigger file to ensure has noticeable cost
-		err := createFileWithSize(file, fileSize)
+		err := createFileWithSize(t, file, fileSize)
 		require.Nil(t, err)
 
 		uploadParams := map[string]interface{}{
@@ -1257,15 +1256,12 @@ func shareFile(t *test.SystemTest, cliConfigFilename string, param map[string]in
 
 func shareFileWithWallet(t *test.SystemTest, wallet, cliConfigFilename string, param map[string]interface{}) ([]string, error) {
 	t.Logf("Sharing file...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox share %s --silent --wallet %s_wallet.json --configDir ./config --config %s",
-		p,
-		wallet,
-		cliConfigFilename,
-	)
-
-	return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+	cmd := cliutils.Zbox("share").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
+	return cmd.Run(t, 3, time.Second*2)
 }
 
 func registerAndCreateAllocation(t *test.SystemTest, configPath, wallet string) (string, *climodel.Wallet) {
@@ -32,7 +32,7 @@ func TestStakeUnstakeTokens(testSetup *testing.T) {
 		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
 
 		blobbers := []climodel.BlobberInfo{}
-		output, err = listBlobbers(t, configPath, "--json")
+		output, err = listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
@@ -172,7 +172,7 @@ func TestStakeUnstakeTokens(testSetup *testing.T) {
 		require.Regexp(t, regexp.MustCompile(`Balance: 1.000 ZCN \(\d*\.?\d+ USD\)$`), output[0])
 
 		blobbers := []climodel.BlobberInfo{}
-		output, err = listBlobbers(t, configPath, "--json")
+		output, err = listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
@@ -213,7 +213,7 @@ func TestStakeUnstakeTokens(testSetup *testing.T) {
 		require.Regexp(t, regexp.MustCompile(`Balance: 1.000 ZCN \(\d*\.?\d+ USD\)$`), output[0])
 
 		blobbers := []climodel.BlobberInfo{}
-		output, err = listBlobbers(t, configPath, "--json")
+		output, err = listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
@@ -255,7 +255,7 @@ func TestStakeUnstakeTokens(testSetup *testing.T) {
 		require.Regexp(t, regexp.MustCompile(`Balance: 1.000 ZCN \(\d*\.?\d+ USD\)$`), output[0])
 
 		blobbers := []climodel.BlobberInfo{}
-		output, err = listBlobbers(t, configPath, "--json")
+		output, err = listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 		require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 
@@ -283,34 +283,53 @@ func TestStakeUnstakeTokens(testSetup *testing.T) {
 	})
 }
 
-func listBlobbers(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func listBlobbers(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Log("Requesting blobber list...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zbox ls-blobbers %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zbox("ls-blobbers").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
-func stakeTokens(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func stakeTokens(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("Staking tokens...")
-	cmd := fmt.Sprintf("./zbox sp-lock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename)
+	cmd := cliutils.Zbox("sp-lock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func stakePoolInfo(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func stakePoolInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Log("Fetching stake pool info...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zbox sp-info %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zbox("sp-info").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
-func unstakeTokens(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func unstakeTokens(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Log("Unlocking tokens from stake pool...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zbox sp-unlock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zbox("sp-unlock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func getBlobbersList(t *test.SystemTest) []climodel.BlobberInfo {
 	blobbers := []climodel.BlobberInfo{}
-	output, err := listBlobbers(t, configPath, "--json")
+	output, err := listBlobbers(t, configPath, createParams(map[string]interface{}{"json": ""}))
 	require.Nil(t, err, "Error listing blobbers", strings.Join(output, "\n"))
 	require.Len(t, output, 1)
 
@@ -370,18 +370,18 @@ func TestSyncWithBlobbers(testSetup *testing.T) {
 		createAllocationTestTeardown(t, allocationID)
 
 		// Create a file locally
-		fileLocalFolder := filepath.Join(os.TempDir(), cliutils.RandomAlphaNumericString(10))
+		fileLocalFolder := filepath.Join(os.TempDir(), cliutils.RandomAlphaNumericString(t, 10))
 		err := os.MkdirAll(fileLocalFolder, os.ModePerm)
 		require.Nil(t, err, "cannot create local path folders")
 		fileLocalPath := filepath.Join(fileLocalFolder, originalFileName)
-		err = createFileWithSize(fileLocalPath, 32*KB)
+		err = createFileWithSize(t, fileLocalPath, 32*KB)
 		require.Nil(t, err, "cannot create local file")
 
 		// Upload the file to allocation root before sync
 		output, err := uploadFile(t, configPath, map[string]interface{}{
 			"allocation": allocationID,
-			"remotepath": `"/` + filepath.Base(fileLocalPath) + `"`,
-			"localpath":  `"` + fileLocalPath + `"`,
+			"remotepath": "/" + filepath.Base(fileLocalPath),
+			"localpath":  fileLocalPath,
 		}, true)
 		require.Nil(t, err, strings.Join(output, "\n"))
 		require.Equal(t, 2, len(output))
@@ -447,18 +447,18 @@ func TestSyncWithBlobbers(testSetup *testing.T) {
 		createAllocationTestTeardown(t, allocationID)
 
 		// Create a file locally
-		fileLocalFolder := filepath.Join(os.TempDir(), cliutils.RandomAlphaNumericString(10))
+		fileLocalFolder := filepath.Join(os.TempDir(), cliutils.RandomAlphaNumericString(t, 10))
 		err := os.MkdirAll(fileLocalFolder, os.ModePerm)
 		require.Nil(t, err, "cannot create local path folders")
 		fileLocalPath := filepath.Join(fileLocalFolder, originalFileName)
-		err = createFileWithSize(fileLocalPath, 64*KB)
+		err = createFileWithSize(t, fileLocalPath, 64*KB)
 		require.Nil(t, err, "cannot create local file")
 
 		// Upload the file to allocation root before sync
 		output, err := uploadFile(t, configPath, map[string]interface{}{
 			"allocation": allocationID,
-			"remotepath": `"/` + filepath.Base(fileLocalPath) + `"`,
-			"localpath":  `"` + fileLocalPath + `"`,
+			"remotepath": "/" + filepath.Base(fileLocalPath),
+			"localpath":  fileLocalPath,
 		}, true)
 		require.Nil(t, err, strings.Join(output, "\n"))
 		require.Equal(t, 2, len(output))
@@ -588,7 +588,7 @@ func TestSyncWithBlobbers(testSetup *testing.T) {
 			"abc.txt": 128 * KB, // Create a file with same name but different size
 		}
 
-		rootFolder := filepath.Join(os.TempDir(), cliutils.RandomAlphaNumericString(10))
+		rootFolder := filepath.Join(os.TempDir(), cliutils.RandomAlphaNumericString(t, 10))
 		localCachePath := filepath.Join(rootFolder, "localcache.json")
 
 		// Create files and folders based on defined structure recursively
@@ -629,6 +629,8 @@ func TestSyncWithBlobbers(testSetup *testing.T) {
 	})
 
 	t.Run("Sync path with uploadonly flag should work", func(t *test.SystemTest) {
+		t.RequireCLI("zbox", "", "sync --uploadonly")
+
 		allocationID := setupAllocation(t, configPath, map[string]interface{}{"size": 2 * MB})
 		createAllocationTestTeardown(t, allocationID)
 
@@ -766,18 +768,15 @@ func syncFolder(t *test.SystemTest, cliConfigFilename string, param map[string]i
 func syncFolderWithWallet(t *test.SystemTest, wallet, cliConfigFilename string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Syncing folder...")
 
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox sync %s --silent --wallet %s_wallet.json --configDir ./config --config %s",
-		p,
-		wallet,
-		cliConfigFilename,
-	)
-
+	cmd := cliutils.Zbox("sync").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*40)
+		return cmd.Run(t, 3, time.Second*40)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -788,18 +787,15 @@ func getDifferences(t *test.SystemTest, cliConfigFilename string, param map[stri
 func getDifferencesWithWallet(t *test.SystemTest, wallet, cliConfigFilename string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Get Differences...")
 
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox get-diff %s --silent --wallet %s_wallet.json --configDir ./config --config %s",
-		p,
-		wallet,
-		cliConfigFilename,
-	)
-
+	cmd := cliutils.Zbox("get-diff").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*40)
+		return cmd.Run(t, 3, time.Second*40)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -823,7 +819,7 @@ func getDifferencesWithWallet(t *test.SystemTest, wallet, cliConfigFilename stri
 //     }
 func createMockFolders(t *test.SystemTest, rootFolder string, structure map[string]interface{}) (string, error) {
 	if rootFolder == "" || rootFolder == "/" {
-		rootFolder = filepath.Join(os.TempDir(), "to-sync", cliutils.RandomAlphaNumericString(10))
+		rootFolder = filepath.Join(os.TempDir(), "to-sync", cliutils.RandomAlphaNumericString(t, 10))
 	}
 	err := os.MkdirAll(rootFolder, os.ModePerm)
 	if err != nil {
@@ -834,7 +830,7 @@ func createMockFolders(t *test.SystemTest, rootFolder string, structure map[stri
 		switch v := value.(type) {
 		case int:
 			localpath := path.Join(rootFolder, name)
-			err := createFileWithSize(localpath, int64(v))
+			err := createFileWithSize(t, localpath, int64(v))
 			if err != nil {
 				return rootFolder, err
 			}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
 
 	cliutils "github.com/0chain/system_test/internal/cli/util"
 	"github.com/stretchr/testify/require"
@@ -81,7 +82,7 @@ func TestTransferAllocation(testSetup *testing.T) { // nolint:gocyclo // team pr
 		})
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, 256)
+		err := createFileWithSize(t, file, 256)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -148,7 +149,7 @@ func TestTransferAllocation(testSetup *testing.T) { // nolint:gocyclo // team pr
 		})
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, 256)
+		err := createFileWithSize(t, file, 256)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -234,7 +235,7 @@ func TestTransferAllocation(testSetup *testing.T) { // nolint:gocyclo // team pr
 		})
 
 		file := generateRandomTestFileName(t)
-		err := createFileWithSize(file, 256)
+		err := createFileWithSize(t, file, 256)
 		require.Nil(t, err)
 
 		filename := filepath.Base(file)
@@ -426,43 +427,39 @@ func transferAllocationOwnership(t *test.SystemTest, param map[string]interface{
 
 func transferAllocationOwnershipWithWallet(t *test.SystemTest, walletName string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Transferring allocation ownership...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zbox transferallocation %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		walletName+"_wallet.json",
-		configPath,
-	)
-
+	cmd := cliutils.Zbox("transferallocation").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func pollForAllocationTransferToEffect(t *test.SystemTest, newOwner, allocationID string) bool {
 	t.Logf("Polling for 5 minutes until allocation ownership changed...")
-	timeout := time.After(time.Minute * 5)
 
 	// this requires the allocation has file uploaded to work properly.
-	for {
+	err := wait.Eventually(t.Context(), time.Minute*5, wait.Constant(time.Second*10), func() error {
 		// using `list all` to verify transfer as this check blobber content as opposed to `get allocation` which is based on sharder
 		output, err := listAllWithWallet(t, newOwner, configPath, allocationID, true)
+		if err != nil {
+			return fmt.Errorf("listing allocation content failed: %w", err)
+		}
 
 		// if not empty, the transfer of allocation contents has occurred on blobbers.
 		// there is only one content expected so once it is no longer empty, transfer is deemed complete.
-		if err == nil && len(output) == 1 && output[0] != "[]" {
-			return true
-		}
-
-		// on timeout, exit with failed transfer allocation.
-		// otherwise, wait and try again
-		select {
-		case <-timeout:
-			return false
-		default:
-			cliutils.Wait(t, time.Second*10)
+		if len(output) != 1 || output[0] == "[]" {
+			return fmt.Errorf("allocation content is not yet visible to new owner: %s", strings.Join(output, "\n"))
 		}
+		return nil
+	})
+	if err != nil {
+		t.Logf("Allocation ownership did not change: %v", err)
+		return false
 	}
+	return true
 }
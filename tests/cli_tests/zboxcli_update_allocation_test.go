@@ -4,8 +4,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
@@ -162,7 +162,7 @@ func TestUpdateAllocation(testSetup *testing.T) {
 		allocationID, allocationBeforeUpdate := setupAndParseAllocation(t, configPath) // alloc size is 10000
 
 		filename := generateRandomTestFileName(t)
-		err := createFileWithSize(filename, 2048) // uploading a file of size 2048
+		err := createFileWithSize(t, filename, 2048) // uploading a file of size 2048
 		require.Nil(t, err)
 
 		output, err := uploadFile(t, configPath, map[string]interface{}{
@@ -876,70 +876,61 @@ func getAllocationCost(str string) (float64, error) {
 	return allocationCostInZCN, nil
 }
 
-func createParams(params map[string]interface{}) string {
-	var builder strings.Builder
-
-	for k, v := range params {
-		if v == nil {
-			_, _ = builder.WriteString(fmt.Sprintf("--%s ", k))
-		} else if reflect.TypeOf(v).String() == "bool" {
-			_, _ = builder.WriteString(fmt.Sprintf("--%s=%v ", k, v))
-		} else {
-			_, _ = builder.WriteString(fmt.Sprintf("--%s %v ", k, v))
+// createParams returns the params as command flags, an empty string value is passed as a flag without value
+func createParams(params map[string]interface{}) cliutils.Flags {
+	flags := make(cliutils.Flags, len(params))
+	for name, value := range params {
+		if value == "" {
+			value = nil
 		}
+		flags[name] = value
 	}
-	return strings.TrimSpace(builder.String())
+	return flags
 }
 
-func createKeyValueParams(params map[string]string) string {
-	keys := "--keys \""
-	values := "--values \""
-	first := true
-	for k, v := range params {
-		if first {
-			first = false
-		} else {
-			keys += ","
-			values += ","
-		}
-		keys += " " + k
-		values += " " + v
+// createKeyValueParams returns the --keys and --values flags of an update-config command
+func createKeyValueParams(params map[string]string) cliutils.Flags {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, params[key])
 	}
-	keys += "\""
-	values += "\""
-	return keys + " " + values
+	return cliutils.Flags{"keys": strings.Join(keys, ","), "values": strings.Join(values, ",")}
 }
 
-func updateAllocation(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func updateAllocation(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return updateAllocationWithWallet(t, escapedTestName(t), cliConfigFilename, params, retry)
 }
 
-func updateAllocationWithWallet(t *test.SystemTest, wallet, cliConfigFilename, params string, retry bool) ([]string, error) {
+func updateAllocationWithWallet(t *test.SystemTest, wallet, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Updating allocation...")
-	cmd := fmt.Sprintf(
-		"./zbox updateallocation %s --silent --wallet %s "+
-			"--configDir ./config --config %s --lock 0.2",
-		params,
-		wallet+"_wallet.json",
-		cliConfigFilename,
-	)
+	cmd := cliutils.Zbox("updateallocation").
+		WithFlags(params).
+		WithFlag("lock", 0.2).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func listAllocations(t *test.SystemTest, cliConfigFilename string) ([]string, error) {
 	cliutils.Wait(t, 5*time.Second)
 	t.Logf("Listing allocations...")
-	cmd := fmt.Sprintf(
-		"./zbox listallocations --json --silent "+
-			"--wallet %s --configDir ./config --config %s",
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename,
-	)
-	return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+	return cliutils.Zbox("listallocations").
+		WithFlag("json", nil).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 // executeFaucetWithTokens executes faucet command with given tokens.
@@ -952,10 +943,10 @@ func executeFaucetWithTokens(t *test.SystemTest, cliConfigFilename string, token
 // Tokens greater than or equal to 10 are considered to be 1 token by the system.
 func executeFaucetWithTokensForWallet(t *test.SystemTest, wallet, cliConfigFilename string, tokens float64) ([]string, error) {
 	t.Logf("Executing faucet...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zwallet faucet --methodName "+
-		"pour --tokens %f --input {} --silent --wallet %s_wallet.json --configDir ./config --config %s",
-		tokens,
-		wallet,
-		cliConfigFilename,
-	), 3, time.Second*5)
+	return cliutils.Zwallet("faucet").
+		WithFlags(cliutils.Flags{"methodName": "pour", "tokens": fmt.Sprintf("%f", tokens), "input": "{}"}).
+		WithSilent().
+		WithWallet(wallet+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*5)
 }
@@ -84,7 +84,12 @@ func TestFileUploadTokenMovement(testSetup *testing.T) {
 
 func getUploadCostInUnit(t *test.SystemTest, cliConfigFilename, allocationID, localpath string) ([]string, error) {
 	t.Logf("Getting upload cost...")
-	output, err := cliutils.RunCommand(t, "./zbox get-upload-cost --allocation "+allocationID+" --localpath "+localpath+" --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	output, err := cliutils.Zbox("get-upload-cost").
+		WithFlags(cliutils.Flags{"allocation": allocationID, "localpath": localpath}).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 	require.Nil(t, err, "error getting upload cost in unit", strings.Join(output, "\n"))
 	require.Len(t, output, 1)
 	return output, err
@@ -92,7 +97,12 @@ func getUploadCostInUnit(t *test.SystemTest, cliConfigFilename, allocationID, lo
 
 func challengePoolInfo(t *test.SystemTest, cliConfigFilename, allocationID string) ([]string, error) {
 	t.Logf("Getting challenge pool info...")
-	return cliutils.RunCommand(t, "./zbox cp-info --allocation "+allocationID+" --json --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	return cliutils.Zbox("cp-info").
+		WithFlags(cliutils.Flags{"allocation": allocationID, "json": nil}).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func intToZCN(balance int64) float64 {
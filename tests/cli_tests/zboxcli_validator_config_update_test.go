@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -141,17 +140,32 @@ func TestValidatorConfigUpdate(testSetup *testing.T) {
 	})
 }
 
-func listValidators(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func listValidators(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Log("Requesting validator list...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zbox ls-validators %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zbox("ls-validators").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
-func getValidatorInfo(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func getValidatorInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Log("Requesting validator info...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zbox validator-info %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zbox("validator-info").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
-func updateValidatorInfo(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
+func updateValidatorInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
 	t.Log("Updating validator info...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zbox validator-update %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, blobberOwnerWallet, cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zbox("validator-update").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(blobberOwnerWallet+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
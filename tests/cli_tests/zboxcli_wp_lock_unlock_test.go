@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -359,26 +358,34 @@ func TestWritePoolLockUnlock(testSetup *testing.T) {
 	})
 }
 
-func writePoolLock(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func writePoolLock(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return writePoolLockWithWallet(t, escapedTestName(t), cliConfigFilename, params, retry)
 }
 
-func writePoolLockWithWallet(t *test.SystemTest, wallet, cliConfigFilename, params string, retry bool) ([]string, error) {
+func writePoolLockWithWallet(t *test.SystemTest, wallet, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Locking write tokens...")
-	cmd := fmt.Sprintf("./zbox wp-lock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename)
+	cmd := cliutils.Zbox("wp-lock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func writePoolUnlock(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func writePoolUnlock(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Unlocking write tokens...")
-	cmd := fmt.Sprintf("./zbox wp-unlock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, escapedTestName(t), cliConfigFilename)
+	cmd := cliutils.Zbox("wp-unlock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"math"
 	"os"
 	"regexp"
@@ -515,7 +514,7 @@ func TestVestingPoolAdd(testSetup *testing.T) {
 
 		var invalidDescription string
 		if maxDescriptionLengthAllowed, ok := vpConfigMap[maxDescriptionLength].(int); ok {
-			invalidDescription = cliutils.RandomAlphaNumericString(int(maxDescriptionLengthAllowed + 1))
+			invalidDescription = cliutils.RandomAlphaNumericString(t, int(maxDescriptionLengthAllowed+1))
 		}
 
 		// add a vesting pool for sending 0.1 to target wallet
@@ -725,14 +724,17 @@ func TestVestingPoolDelete(testSetup *testing.T) {
 	})
 }
 
-func vestingPoolDelete(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func vestingPoolDelete(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("Deleting vesting pool...")
+	cmd := cliutils.Zwallet("vp-delete").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, "./zwallet vp-delete "+params+
-			" --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*5)
+		return cmd.Run(t, 3, time.Second*5)
 	} else {
-		return cliutils.RunCommandWithoutRetry("./zwallet vp-delete " + params +
-			" --silent --wallet " + escapedTestName(t) + "_wallet.json" + " --configDir ./config --config " + cliConfigFilename)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -1909,97 +1911,110 @@ func TestVestingPoolUpdateConfig(testSetup *testing.T) {
 func getVestingPoolSCConfig(t *test.SystemTest, cliConfigFilename string, retry bool) ([]string, error) {
 	cliutils.Wait(t, 5*time.Second)
 	t.Logf("Retrieving vesting config...")
-
-	cmd := "./zwallet vp-config --silent --wallet " + escapedTestName(t) + "_wallet.json --configDir ./config --config " + cliConfigFilename
-
+	cmd := cliutils.Zwallet("vp-config").
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func updateVestingPoolSCConfig(t *test.SystemTest, walletName string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Updating vesting config...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zwallet vp-update-config %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		walletName+"_wallet.json",
-		configPath,
-	)
-
+	cmd := cliutils.Zwallet("vp-update-config").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func vestingPoolUnlock(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func vestingPoolUnlock(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return vestingPoolUnlockForWallet(t, cliConfigFilename, params, retry, escapedTestName(t))
 }
 
-func vestingPoolUnlockForWallet(t *test.SystemTest, cliConfigFilename, params string, retry bool, wallet string) ([]string, error) {
+func vestingPoolUnlockForWallet(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool, wallet string) ([]string, error) {
 	t.Log("Unlocking a vesting pool...")
+	cmd := cliutils.Zwallet("vp-unlock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, "./zwallet vp-unlock "+params+
-			" --silent --wallet "+wallet+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*5)
+		return cmd.Run(t, 3, time.Second*5)
 	} else {
-		return cliutils.RunCommandWithoutRetry("./zwallet vp-unlock " + params +
-			" --silent --wallet " + wallet + "_wallet.json" + " --configDir ./config --config " + cliConfigFilename)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func vestingPoolTrigger(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func vestingPoolTrigger(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return vestingPoolTriggerForWallet(t, cliConfigFilename, params, retry, escapedTestName(t))
 }
 
-func vestingPoolTriggerForWallet(t *test.SystemTest, cliConfigFilename, params string, retry bool, wallet string) ([]string, error) {
+func vestingPoolTriggerForWallet(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool, wallet string) ([]string, error) {
 	t.Log("Triggering vesting pool...")
+	cmd := cliutils.Zwallet("vp-trigger").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, "./zwallet vp-trigger "+params+
-			" --silent --wallet "+wallet+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*5)
+		return cmd.Run(t, 3, time.Second*5)
 	} else {
-		return cliutils.RunCommandWithoutRetry("./zwallet vp-trigger " + params +
-			" --silent --wallet " + wallet + "_wallet.json" + " --configDir ./config --config " + cliConfigFilename)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func vestingPoolStop(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func vestingPoolStop(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("Stopping vesting pool...")
+	cmd := cliutils.Zwallet("vp-stop").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, "./zwallet vp-stop "+params+
-			" --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*5)
+		return cmd.Run(t, 3, time.Second*5)
 	} else {
-		return cliutils.RunCommandWithoutRetry("./zwallet vp-stop " + params +
-			" --silent --wallet " + escapedTestName(t) + "_wallet.json" + " --configDir ./config --config " + cliConfigFilename)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func vestingPoolInfo(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func vestingPoolInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("fetching vesting pool info...")
+	cmd := cliutils.Zwallet("vp-info").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, "./zwallet vp-info "+params+
-			" --silent --wallet "+escapedTestName(t)+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*5)
+		return cmd.Run(t, 3, time.Second*5)
 	} else {
-		return cliutils.RunCommandWithoutRetry("./zwallet vp-info " + params +
-			" --silent --wallet " + escapedTestName(t) + "_wallet.json" + " --configDir ./config --config " + cliConfigFilename)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func vestingPoolAdd(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func vestingPoolAdd(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("Adding a new vesting pool...")
 	return vestingPoolAddForWallet(t, cliConfigFilename, params, retry, escapedTestName(t))
 }
 
-func vestingPoolAddForWallet(t *test.SystemTest, cliConfigFilename, params string, retry bool, walletName string) ([]string, error) {
+func vestingPoolAddForWallet(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool, walletName string) ([]string, error) {
+	cmd := cliutils.Zwallet("vp-add").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, "./zwallet vp-add "+params+
-			" --silent --wallet "+walletName+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry("./zwallet vp-add " + params +
-			" --silent --wallet " + walletName + "_wallet.json" + " --configDir ./config --config " + cliConfigFilename)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
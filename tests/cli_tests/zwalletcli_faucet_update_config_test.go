@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -16,12 +15,13 @@ import (
 
 func TestFaucetUpdateConfig(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagOwnerConfig)
 
 	// register SC owner wallet
 	output, err := registerWalletForName(t, configPath, scOwnerWallet)
 	require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-	t.RunSequentially("should allow update of max_pour_amount", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.FaucetSCConfigLock), "should allow update of max_pour_amount", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -74,7 +74,7 @@ func TestFaucetUpdateConfig(testSetup *testing.T) {
 		require.Nil(t, err, "faucet execution failed", strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update max_pour_amount to invalid value should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.FaucetSCConfigLock), "update max_pour_amount to invalid value should fail", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -91,7 +91,7 @@ func TestFaucetUpdateConfig(testSetup *testing.T) {
 		require.Equal(t, "update_settings: key max_pour_amount, unable to convert x to state.balance", output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update by non-smartcontract owner should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.FaucetSCConfigLock), "update by non-smartcontract owner should fail", func(t *test.SystemTest) {
 		configKey := "max_pour_amount"
 		newValue := "15"
 
@@ -108,7 +108,7 @@ func TestFaucetUpdateConfig(testSetup *testing.T) {
 		require.Equal(t, "update_settings: unauthorized access - only the owner can access", output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update with bad config key should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.FaucetSCConfigLock), "update with bad config key should fail", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -125,7 +125,7 @@ func TestFaucetUpdateConfig(testSetup *testing.T) {
 		require.Equal(t, "update_settings: key unknown_key not recognised as setting", output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update with missing keys param should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.FaucetSCConfigLock), "update with missing keys param should fail", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -139,7 +139,7 @@ func TestFaucetUpdateConfig(testSetup *testing.T) {
 		require.Equal(t, "number keys must equal the number values", output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update with missing values param should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.FaucetSCConfigLock), "update with missing values param should fail", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -157,29 +157,27 @@ func TestFaucetUpdateConfig(testSetup *testing.T) {
 func getFaucetSCConfig(t *test.SystemTest, cliConfigFilename string, retry bool) ([]string, error) {
 	cliutils.Wait(t, 5*time.Second)
 	t.Logf("Retrieving faucet config...")
-
-	cmd := "./zwallet fc-config --silent --wallet " + escapedTestName(t) + "_wallet.json --configDir ./config --config " + cliConfigFilename
-
+	cmd := cliutils.Zwallet("fc-config").
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func updateFaucetSCConfig(t *test.SystemTest, walletName string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Updating faucet config...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zwallet fc-update-config %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		walletName+"_wallet.json",
-		configPath,
-	)
-
+	cmd := cliutils.Zwallet("fc-update-config").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
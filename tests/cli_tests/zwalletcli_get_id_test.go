@@ -60,9 +60,13 @@ func TestGetId(testSetup *testing.T) {
 
 func getId(t *test.SystemTest, cliConfigFilename, url string, retry bool) ([]string, error) {
 	t.Logf("getting id for [%s]...", url)
+	cmd := cliutils.Zwallet("getid").
+		WithFlag("url", url).
+		WithSilent().
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, fmt.Sprintf("./zwallet getid --silent --configDir ./config --url %s --config %s", url, cliConfigFilename), 3, time.Second)
+		return cmd.Run(t, 3, time.Second)
 	} else {
-		return cliutils.RunCommandWithoutRetry(fmt.Sprintf("./zwallet getid --silent --configDir ./config --url %s --config %s", url, cliConfigFilename))
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
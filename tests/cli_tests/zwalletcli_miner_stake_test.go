@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -13,6 +12,7 @@ import (
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/wait"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
@@ -26,7 +26,7 @@ func TestMinerStake(testSetup *testing.T) {
 		t.Skipf("miner node owner wallet located at %s is missing", "./config/"+miner01NodeDelegateWalletName+"_wallet.json")
 	}
 
-	output, err := listMiners(t, configPath, "--json")
+	output, err := listMiners(t, configPath, createParams(map[string]interface{}{"json": ""}))
 	require.Nil(t, err, "error listing miners")
 	require.Len(t, output, 1)
 
@@ -91,7 +91,7 @@ func TestMinerStake(testSetup *testing.T) {
 		require.Nil(t, err, "error executing faucet", strings.Join(output, "\n"))
 
 		var poolsInfoBefore climodel.MinerSCUserPoolsInfo
-		output, err = stakePoolsInMinerSCInfo(t, configPath, "", true)
+		output, err = stakePoolsInMinerSCInfo(t, configPath, nil, true)
 		require.Nil(t, err, "error fetching Miner SC User pools")
 		require.Len(t, output, 1)
 		err = json.Unmarshal([]byte(output[0]), &poolsInfoBefore)
@@ -118,7 +118,7 @@ func TestMinerStake(testSetup *testing.T) {
 		require.Regexp(t, regexp.MustCompile("locked with: [a-z0-9]{64}"), output[0])
 
 		var poolsInfo climodel.MinerSCUserPoolsInfo
-		output, err = stakePoolsInMinerSCInfo(t, configPath, "", true)
+		output, err = stakePoolsInMinerSCInfo(t, configPath, nil, true)
 		require.Nil(t, err, "error fetching Miner SC User pools")
 		require.Len(t, output, 1)
 
@@ -378,10 +378,9 @@ func TestMinerStake(testSetup *testing.T) {
 
 func pollForPoolInfo(t *test.SystemTest, minerID string) (climodel.DelegatePool, error) {
 	t.Log(`polling for pool info till it is "ACTIVE"...`)
-	timeout := time.After(time.Minute * 5)
 
 	var poolsInfo climodel.DelegatePool
-	for {
+	err := wait.Eventually(t.Context(), time.Minute*5, wait.Constant(time.Second*15), func() error {
 		output, err := minerSharderPoolInfo(t, configPath, createParams(map[string]interface{}{
 			"id": minerID,
 		}), true)
@@ -392,28 +391,32 @@ func pollForPoolInfo(t *test.SystemTest, minerID string) (climodel.DelegatePool,
 		require.Nil(t, err, "error unmarshalling Miner Sharder pools")
 		require.NotEmpty(t, poolsInfo)
 
-		if poolsInfo.Status == int(climodel.Active) {
-			return poolsInfo, nil
-		}
-		select {
-		case <-timeout:
-			return climodel.DelegatePool{}, errors.New("Pool status did not change to active")
-		default:
-			cliutils.Wait(t, time.Second*15)
+		if poolsInfo.Status != int(climodel.Active) {
+			return fmt.Errorf("pool status is %d", poolsInfo.Status)
 		}
+		return nil
+	})
+	if err != nil {
+		return climodel.DelegatePool{}, fmt.Errorf("Pool status did not change to active: %w", err)
 	}
+	return poolsInfo, nil
 }
 
-func minerSharderPoolInfo(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func minerSharderPoolInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return minerSharderPoolInfoForWallet(t, cliConfigFilename, params, escapedTestName(t), retry)
 }
 
-func minerSharderPoolInfoForWallet(t *test.SystemTest, cliConfigFilename, params, wallet string, retry bool) ([]string, error) {
+func minerSharderPoolInfoForWallet(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, wallet string, retry bool) ([]string, error) {
 	t.Log("fetching mn-pool-info...")
+	cmd := cliutils.Zwallet("mn-pool-info").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, fmt.Sprintf("./zwallet mn-pool-info %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename), 3, time.Second)
+		return cmd.Run(t, 3, time.Second)
 	} else {
-		return cliutils.RunCommandWithoutRetry(fmt.Sprintf("./zwallet mn-pool-info %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename))
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -449,28 +452,38 @@ func getBalanceFromSharders(t *test.SystemTest, clientId string) int64 {
 	return startBalance.Balance
 }
 
-func minerOrSharderLock(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func minerOrSharderLock(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return minerOrSharderLockForWallet(t, cliConfigFilename, params, escapedTestName(t), retry)
 }
 
-func minerOrSharderLockForWallet(t *test.SystemTest, cliConfigFilename, params, wallet string, retry bool) ([]string, error) {
+func minerOrSharderLockForWallet(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, wallet string, retry bool) ([]string, error) {
 	t.Log("locking tokens against miner/sharder...")
+	cmd := cliutils.Zwallet("mn-lock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, fmt.Sprintf("./zwallet mn-lock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename), 3, time.Second)
+		return cmd.Run(t, 3, time.Second)
 	} else {
-		return cliutils.RunCommandWithoutRetry(fmt.Sprintf("./zwallet mn-lock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename))
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func minerOrSharderUnlock(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func minerOrSharderUnlock(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return minerOrSharderUnlockForWallet(t, cliConfigFilename, params, escapedTestName(t), retry)
 }
 
-func minerOrSharderUnlockForWallet(t *test.SystemTest, cliConfigFilename, params, wallet string, retry bool) ([]string, error) {
+func minerOrSharderUnlockForWallet(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, wallet string, retry bool) ([]string, error) {
 	t.Log("unlocking tokens from miner/sharder pool...")
+	cmd := cliutils.Zwallet("mn-unlock").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, fmt.Sprintf("./zwallet mn-unlock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename), 3, time.Second)
+		return cmd.Run(t, 3, time.Second)
 	} else {
-		return cliutils.RunCommandWithoutRetry(fmt.Sprintf("./zwallet mn-unlock %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename))
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
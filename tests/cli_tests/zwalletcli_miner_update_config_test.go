@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -16,8 +15,9 @@ import (
 
 func TestMinerUpdateConfig(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagOwnerConfig)
 
-	t.RunSequentially("update by non-smartcontract owner should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.MinerSCConfigLock), "update by non-smartcontract owner should fail", func(t *test.SystemTest) {
 		configKey := "reward_rate"
 		newValue := "0.1"
 
@@ -34,7 +34,7 @@ func TestMinerUpdateConfig(testSetup *testing.T) {
 		require.Equal(t, "update_settings: unauthorized access - only the owner can access", output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update with bad config key should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.MinerSCConfigLock), "update with bad config key should fail", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -58,7 +58,7 @@ func TestMinerUpdateConfig(testSetup *testing.T) {
 		require.Equal(t, "update_settings: unsupported key unknown_key", output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update with missing keys param should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.MinerSCConfigLock), "update with missing keys param should fail", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -79,7 +79,7 @@ func TestMinerUpdateConfig(testSetup *testing.T) {
 		require.Equal(t, "number keys must equal the number values", output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update with missing values param should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.MinerSCConfigLock), "update with missing values param should fail", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -103,30 +103,28 @@ func TestMinerUpdateConfig(testSetup *testing.T) {
 
 func getMinerSCConfig(t *test.SystemTest, cliConfigFilename string, retry bool) ([]string, error) {
 	t.Logf("Retrieving miner config...")
-
-	cmd := "./zwallet mn-config --silent --wallet " + escapedTestName(t) + "_wallet.json --configDir ./config --config " + cliConfigFilename
-
+	cmd := cliutils.Zwallet("mn-config").
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func updateMinerSCConfig(t *test.SystemTest, walletName string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Updating miner config...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zwallet mn-update-config %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		walletName+"_wallet.json",
-		configPath,
-	)
-
+	cmd := cliutils.Zwallet("mn-update-config").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*5)
+		return cmd.Run(t, 3, time.Second*5)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -141,8 +139,8 @@ func updateMinerSCConfig(t *test.SystemTest, walletName string, param map[string
 // 	)
 
 // 	if retry {
-// 		return cliutils.RunCommand(t, cmd, 3, time.Second*5)
+// 		return cmd.Run(t, 3, time.Second*5)
 // 	} else {
-// 		return cliutils.RunCommandWithoutRetry(cmd)
+// 		return cmd.RunWithoutRetry(t.Context())
 // 	}
 // }
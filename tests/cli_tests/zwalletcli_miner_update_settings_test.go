@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 	"regexp"
 	"strings"
@@ -13,6 +12,7 @@ import (
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -23,8 +23,9 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 		t.Skipf("miner node owner wallet located at %s is missing", "./config/"+miner01NodeDelegateWalletName+"_wallet.json")
 	}
 
+	t.HoldLocks(test.Shared(test.MinerSCConfigLock))
 	mnConfig := getMinerSCConfiguration(t)
-	output, err := listMiners(t, configPath, "--json")
+	output, err := listMiners(t, configPath, createParams(map[string]interface{}{"json": ""}))
 	require.Nil(t, err, "error listing miners")
 	require.Len(t, output, 1)
 
@@ -212,13 +213,15 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 		var minerInfo climodel.Node
 		err = json.Unmarshal([]byte(output[0]), &minerInfo)
 		require.Nil(t, err, "error unmarshalling miner info")
-		require.Equal(t, 5, minerInfo.Settings.MaxNumDelegates)
+		assert.Equal(t.Check(), 5, minerInfo.Settings.MaxNumDelegates, "number of delegates")
 		max_stake, err := minerInfo.Settings.MaxStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 99, int(intToZCN(max_stake)))
+		if assert.Nil(t.Check(), err, "max stake") {
+			assert.Equal(t.Check(), 99, int(intToZCN(max_stake)), "max stake")
+		}
 		min_stake, err := minerInfo.Settings.MinStake.Int64()
-		require.Nil(t, err)
-		require.Equal(t, 1, int(intToZCN(min_stake)))
+		if assert.Nil(t.Check(), err, "min stake") {
+			assert.Equal(t.Check(), 1, int(intToZCN(min_stake)), "min stake")
+		}
 	})
 
 	t.RunSequentiallyWithTimeout("Miner update min_stake with less than global min stake should fail", 60*time.Second, func(t *test.SystemTest) {
@@ -409,7 +412,7 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 			}
 		}
 
-		output, err := minerSharderUpdateSettings(t, configPath, miner01NodeDelegateWalletName, "", false)
+		output, err := minerSharderUpdateSettings(t, configPath, miner01NodeDelegateWalletName, nil, false)
 		require.NotNil(t, err, "expected error trying to update miner node settings without id, but got output:", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		require.Equal(t, "missing id flag", output[0])
@@ -476,23 +479,34 @@ func TestMinerUpdateSettings(testSetup *testing.T) { // nolint cyclomatic comple
 	})
 }
 
-func listMiners(t *test.SystemTest, cliConfigFilename, params string) ([]string, error) {
-	return cliutils.RunCommand(t, fmt.Sprintf("./zwallet ls-miners %s --active --silent --wallet %s_wallet.json --configDir ./config --config %s", params, miner01NodeDelegateWalletName, cliConfigFilename), 3, time.Second*2)
+func listMiners(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags) ([]string, error) {
+	return cliutils.Zwallet("ls-miners").
+		WithFlags(params).
+		WithFlag("active", nil).
+		WithSilent().
+		WithWallet(miner01NodeDelegateWalletName+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func getNonceForWallet(t *test.SystemTest, cliConfigFilename, wallet string, retry bool) ([]string, error) {
 	t.Log("Updating miner settings...")
-	cmd := fmt.Sprintf("./zwallet getnonce --silent --wallet %s_wallet.json --configDir ./config --config %s", wallet, cliConfigFilename)
+	cmd := cliutils.Zwallet("getnonce").WithSilent().WithWallet(wallet + "_wallet.json").WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
-func minerInfo(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func minerInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Log("Fetching miner node info...")
-	return cliutils.RunCommand(t, fmt.Sprintf("./zwallet mn-info %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, miner01NodeDelegateWalletName, cliConfigFilename), 3, time.Second*2)
+	return cliutils.Zwallet("mn-info").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(miner01NodeDelegateWalletName+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func getCurrentRound(t *test.SystemTest) int64 {
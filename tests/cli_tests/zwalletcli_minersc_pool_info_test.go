@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -28,7 +27,7 @@ func TestMinerSCUserPoolInfo(testSetup *testing.T) {
 		require.NoError(t, err)
 
 		// before locking tokens against a miner
-		output, err = stakePoolsInMinerSCInfo(t, configPath, "", true)
+		output, err = stakePoolsInMinerSCInfo(t, configPath, nil, true)
 		require.Nil(t, err, "error fetching stake pools")
 		require.Len(t, output, 1)
 
@@ -46,7 +45,7 @@ func TestMinerSCUserPoolInfo(testSetup *testing.T) {
 		require.Regexp(t, regexp.MustCompile("locked with: [a-z0-9]{64}"), output[0])
 
 		// after locking tokens against a miner
-		output, err = stakePoolsInMinerSCInfo(t, configPath, "", true)
+		output, err = stakePoolsInMinerSCInfo(t, configPath, nil, true)
 		require.Nil(t, err, "error fetching Miner SC User pools")
 		require.Len(t, output, 1)
 
@@ -73,7 +72,7 @@ func TestMinerSCUserPoolInfo(testSetup *testing.T) {
 		require.Nil(t, err, "error executing faucet", strings.Join(output, "\n"))
 
 		// before locking tokens against a sharder
-		output, err = stakePoolsInMinerSCInfo(t, configPath, "", true)
+		output, err = stakePoolsInMinerSCInfo(t, configPath, nil, true)
 		require.Nil(t, err, "error fetching stake pools")
 		require.Len(t, output, 1)
 
@@ -93,7 +92,7 @@ func TestMinerSCUserPoolInfo(testSetup *testing.T) {
 		require.NoError(t, err)
 
 		// after locking tokens against sharder
-		output, err = stakePoolsInMinerSCInfo(t, configPath, "", true)
+		output, err = stakePoolsInMinerSCInfo(t, configPath, nil, true)
 		require.Nil(t, err, "error fetching Miner SC User pools")
 		require.Len(t, output, 1)
 
@@ -181,15 +180,21 @@ func TestMinerSCUserPoolInfo(testSetup *testing.T) {
 	})
 }
 
-func stakePoolsInMinerSCInfo(t *test.SystemTest, cliConfigFilename, params string, retry bool) ([]string, error) {
+func stakePoolsInMinerSCInfo(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, retry bool) ([]string, error) {
 	return stakePoolsInMinerSCInfoForWallet(t, cliConfigFilename, params, escapedTestName(t), retry)
 }
 
-func stakePoolsInMinerSCInfoForWallet(t *test.SystemTest, cliConfigFilename, params, wallet string, retry bool) ([]string, error) {
+func stakePoolsInMinerSCInfoForWallet(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, wallet string, retry bool) ([]string, error) {
 	t.Log("fetching mn-user-info...")
+	cmd := cliutils.Zwallet("mn-user-info").
+		WithFlags(params).
+		WithFlag("json", nil).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, fmt.Sprintf("./zwallet mn-user-info %s --json --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename), 3, time.Second)
+		return cmd.Run(t, 3, time.Second)
 	} else {
-		return cliutils.RunCommandWithoutRetry(fmt.Sprintf("./zwallet mn-user-info %s --json --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename))
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -87,9 +87,11 @@ func TestMultisigWallet(testSetup *testing.T) {
 	})
 
 	t.Run("Wallet Creation should fail when args not set", func(t *test.SystemTest) {
-		output, err := cliutils.RunCommandWithoutRetry(fmt.Sprintf("./zwallet createmswallet "+
-			"--silent --wallet %s --configDir ./config --config %s", escapedTestName(t)+
-			"_wallet.json", configPath))
+		output, err := cliutils.Zwallet("createmswallet").
+			WithSilent().
+			WithWallet(escapedTestName(t) + "_wallet.json").
+			WithConfig(configPath).
+			RunWithoutRetry(t.Context())
 
 		require.NotNil(t, err, "expected command to fail", strings.Join(output, "\n"))
 		require.True(t, len(output) > 0, "Output was less than number of "+
@@ -99,9 +101,12 @@ func TestMultisigWallet(testSetup *testing.T) {
 	})
 
 	t.Run("Wallet Creation should fail when threshold not set", func(t *test.SystemTest) {
-		output, err := cliutils.RunCommandWithoutRetry(fmt.Sprintf("./zwallet createmswallet "+
-			"--numsigners %d --silent --wallet %s --configDir ./config "+
-			"--config %s", 3, escapedTestName(t)+"_wallet.json", configPath))
+		output, err := cliutils.Zwallet("createmswallet").
+			WithFlag("numsigners", 3).
+			WithSilent().
+			WithWallet(escapedTestName(t) + "_wallet.json").
+			WithConfig(configPath).
+			RunWithoutRetry(t.Context())
 
 		require.NotNil(t, err, "expected command to fail", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
@@ -111,14 +116,14 @@ func TestMultisigWallet(testSetup *testing.T) {
 
 func createMultiSigWallet(t *test.SystemTest, cliConfigFilename string, numSigners, threshold int, retry bool) ([]string, error) {
 	t.Logf("Creating multisig wallet...")
-	cmd := fmt.Sprintf(
-		"./zwallet createmswallet --numsigners %d --threshold %d --silent --wallet %s --configDir ./config --config %s",
-		numSigners, threshold,
-		escapedTestName(t)+"_wallet.json",
-		cliConfigFilename)
+	cmd := cliutils.Zwallet("createmswallet").
+		WithFlags(cliutils.Flags{"numsigners": numSigners, "threshold": threshold}).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
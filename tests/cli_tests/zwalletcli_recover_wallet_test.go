@@ -44,9 +44,11 @@ func TestRecoverWallet(testSetup *testing.T) {
 	})
 
 	t.Run("Recover wallet no mnemonic", func(t *test.SystemTest) {
-		output, err := cliutils.RunCommandWithoutRetry("./zwallet recoverwallet --silent " +
-			"--wallet " + escapedTestName(t) + "_wallet.json" + " " +
-			"--configDir ./config --config " + configPath)
+		output, err := cliutils.Zwallet("recoverwallet").
+			WithSilent().
+			WithWallet(escapedTestName(t) + "_wallet.json").
+			WithConfig(configPath).
+			RunWithoutRetry(t.Context())
 
 		require.NotNil(t, err, "expected error to occur recovering a wallet", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
@@ -56,13 +58,14 @@ func TestRecoverWallet(testSetup *testing.T) {
 
 func recoverWalletFromMnemonic(t *test.SystemTest, configPath, mnemonic string, retry bool) ([]string, error) {
 	t.Logf("Recovering wallet from mnemonic...")
-	cmd := "./zwallet recoverwallet " +
-		"--silent --wallet " + escapedTestName(t) + "_wallet.json" + " " +
-		"--configDir ./config --config " + configPath + " --mnemonic \"" + mnemonic + "\""
-
+	cmd := cliutils.Zwallet("recoverwallet").
+		WithFlag("mnemonic", mnemonic).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
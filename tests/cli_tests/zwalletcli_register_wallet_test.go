@@ -17,11 +17,14 @@ import (
 
 func TestRegisterWallet(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagSmoke)
 
 	t.Parallel()
 
 	t.Run("Register wallet outputs expected", func(t *test.SystemTest) {
-		output, err := registerWallet(t, configPath)
+		workspace := newWorkspace(t)
+
+		output, err := workspace.Zbox("register").WithSilent().Run(t, 3, time.Second*2)
 
 		require.Nil(t, err, "An error occurred registering a wallet", strings.Join(output, "\n"))
 		require.Len(t, output, 4)
@@ -32,12 +35,17 @@ func TestRegisterWallet(testSetup *testing.T) {
 	})
 
 	t.Run("Get wallet outputs expected", func(t *test.SystemTest) {
-		output, err := registerWallet(t, configPath)
+		workspace := newWorkspace(t)
+		output, err := workspace.Zbox("register").WithSilent().Run(t, 3, time.Second*2)
 		require.Nil(t, err, "An error occurred registering a wallet", strings.Join(output, "\n"))
 
-		wallet, err := getWallet(t, configPath)
-
+		output, err = workspace.Zbox("getwallet").WithFlag("json", nil).WithSilent().Run(t, 3, time.Second*2)
 		require.Nil(t, err, "An error occurred retrieving a wallet", strings.Join(output, "\n"))
+		require.Len(t, output, 1)
+
+		var wallet *climodel.Wallet
+		err = json.Unmarshal([]byte(output[0]), &wallet)
+		require.Nil(t, err, "failed to unmarshal the result into wallet", output[0])
 		require.NotNil(t, wallet.ClientID)
 		require.NotNil(t, wallet.ClientPublicKey)
 		require.NotNil(t, wallet.EncryptionPublicKey)
@@ -105,8 +113,11 @@ func registerWallet(t *test.SystemTest, cliConfigFilename string) ([]string, err
 
 func registerWalletForName(t *test.SystemTest, cliConfigFilename, name string) ([]string, error) {
 	t.Logf("Registering wallet...")
-	return cliutils.RunCommand(t, "./zbox register --silent "+
-		"--wallet "+name+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	return cliutils.Zbox("register").
+		WithSilent().
+		WithWallet(name+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func registerWalletForNameAndLockReadTokens(t *test.SystemTest, cliConfigFilename, name string) {
@@ -141,8 +152,11 @@ func ensureZeroBalance(t *test.SystemTest, output []string, err error) {
 }
 
 func getBalanceForWallet(t *test.SystemTest, cliConfigFilename, wallet string) ([]string, error) {
-	return cliutils.RunCommand(t, "./zwallet getbalance --silent "+
-		"--wallet "+wallet+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	return cliutils.Zwallet("getbalance").
+		WithSilent().
+		WithWallet(wallet+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func getWallet(t *test.SystemTest, cliConfigFilename string) (*climodel.Wallet, error) {
@@ -151,8 +165,12 @@ func getWallet(t *test.SystemTest, cliConfigFilename string) (*climodel.Wallet,
 
 func getWalletForName(t *test.SystemTest, cliConfigFilename, name string) (*climodel.Wallet, error) {
 	t.Logf("Getting wallet...")
-	output, err := cliutils.RunCommand(t, "./zbox getwallet --json --silent "+
-		"--wallet "+name+"_wallet.json"+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	output, err := cliutils.Zbox("getwallet").
+		WithFlag("json", nil).
+		WithSilent().
+		WithWallet(name+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 
 	if err != nil {
 		return nil, err
@@ -173,8 +191,12 @@ func getWalletForName(t *test.SystemTest, cliConfigFilename, name string) (*clim
 
 func verifyTransaction(t *test.SystemTest, cliConfigFilename, txn string) ([]string, error) {
 	t.Logf("Verifying transaction...")
-	return cliutils.RunCommand(t, "./zwallet verify --silent --wallet "+escapedTestName(t)+""+
-		"_wallet.json"+" --hash "+txn+" --configDir ./config --config "+cliConfigFilename, 3, time.Second*2)
+	return cliutils.Zwallet("verify").
+		WithFlag("hash", txn).
+		WithSilent().
+		WithWallet(escapedTestName(t)+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func escapedTestName(t *test.SystemTest) string {
@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"io"
 	"reflect"
 	"regexp"
@@ -23,6 +22,7 @@ const MINER_SC_ADDRESS = "6dba10422e368813802877a85039d3985d96760ed844092319743f
 
 func TestSendAndBalance(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagSmoke)
 
 	t.Parallel()
 
@@ -127,10 +127,12 @@ func TestSendAndBalance(testSetup *testing.T) {
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Unexpected register wallet failure", strings.Join(output, "\n"))
 
-		output, err = cliutils.RunCommandWithoutRetry("./zwallet send --silent --tokens 1" +
-			" --to_client_id 7ec733204418d72b68e3579bdf55881b1528c676850976920de3f73e45d4fafa" +
-			" --wallet " + escapedTestName(t) + "_wallet.json --configDir ./config --config " + configPath,
-		)
+		output, err = cliutils.Zwallet("send").
+			WithFlags(cliutils.Flags{"tokens": 1, "to_client_id": "7ec733204418d72b68e3579bdf55881b1528c676850976920de3f73e45d4fafa"}).
+			WithSilent().
+			WithWallet(escapedTestName(t) + "_wallet.json").
+			WithConfig(configPath).
+			RunWithoutRetry(t.Context())
 		require.NotNil(t, err, "Expected send to fail", strings.Join(output, "\n"))
 
 		require.Len(t, output, 1)
@@ -300,16 +302,20 @@ func TestSendAndBalance(testSetup *testing.T) {
 	})
 }
 
-func sendZCN(t *test.SystemTest, cliConfigFilename, toClientID, tokens, desc, params string, retry bool) ([]string, error) {
+func sendZCN(t *test.SystemTest, cliConfigFilename, toClientID, tokens, desc string, params cliutils.Flags, retry bool) ([]string, error) {
 	t.Logf("Sending ZCN...")
-	cmd := "./zwallet send --silent --tokens " + tokens +
-		" --desc \"" + desc + "\"" +
-		" --to_client_id " + toClientID + " " + params +
-		" --wallet " + escapedTestName(t) + "_wallet.json --configDir ./config --config " + cliConfigFilename
+	cmd := cliutils.Zwallet("send").
+		WithFlags(params).
+		WithFlag("tokens", tokens).
+		WithFlag("desc", desc).
+		WithFlag("to_client_id", toClientID).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -319,14 +325,16 @@ func sendTokens(t *test.SystemTest, cliConfigFilename, toClientID string, tokens
 
 func sendTokensFromWallet(t *test.SystemTest, cliConfigFilename, toClientID string, tokens float64, desc string, fee float64, wallet string) ([]string, error) {
 	t.Logf("Sending ZCN...")
-	cmd := fmt.Sprintf(`./zwallet send --silent --tokens %v --desc %q --to_client_id %s `, tokens, desc, toClientID)
-
+	flags := cliutils.Flags{"tokens": tokens, "desc": desc, "to_client_id": toClientID}
 	if fee > 0 {
-		cmd += fmt.Sprintf(" --fee %v ", fee)
+		flags["fee"] = fee
 	}
-
-	cmd += fmt.Sprintf(" --wallet %s --configDir ./config --config %s ", wallet+"_wallet.json", cliConfigFilename)
-	return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+	return cliutils.Zwallet("send").
+		WithFlags(flags).
+		WithSilent().
+		WithWallet(wallet+"_wallet.json").
+		WithConfig(cliConfigFilename).
+		Run(t, 3, time.Second*2)
 }
 
 func getRoundBlockFromASharder(t *test.SystemTest, round int64) climodel.Block {
@@ -1,8 +1,8 @@
 package cli_tests
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
 	"os"
 	"regexp"
 	"strings"
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/0chain/system_test/internal/api/util/test"
+	"github.com/0chain/system_test/internal/api/util/watcher"
 
 	climodel "github.com/0chain/system_test/internal/cli/model"
 	cliutils "github.com/0chain/system_test/internal/cli/util"
@@ -22,6 +23,7 @@ func TestSharderStake(testSetup *testing.T) {
 	if _, err := os.Stat("./config/" + sharder01NodeDelegateWalletName + "_wallet.json"); err != nil {
 		t.Skipf("miner node owner wallet located at %s is missing", "./config/"+sharder01NodeDelegateWalletName+"_wallet.json")
 	}
+	t.HoldLocks(test.Shared(test.MinerSCConfigLock))
 
 	sharders := getShardersListForWallet(t, sharder01NodeDelegateWalletName)
 
@@ -82,7 +84,7 @@ func TestSharderStake(testSetup *testing.T) {
 		require.Nil(t, err, "error executing faucet", strings.Join(output, "\n"))
 
 		var poolsInfoBefore climodel.MinerSCUserPoolsInfo
-		output, err = stakePoolsInMinerSCInfo(t, configPath, "", true)
+		output, err = stakePoolsInMinerSCInfo(t, configPath, nil, true)
 		require.Nil(t, err, "error fetching Miner SC User pools")
 		require.Len(t, output, 1)
 		err = json.Unmarshal([]byte(output[0]), &poolsInfoBefore)
@@ -106,7 +108,7 @@ func TestSharderStake(testSetup *testing.T) {
 		require.Len(t, output, 1)
 		require.Regexp(t, regexp.MustCompile("locked with: [0-9a-z]{64}"), output[0])
 
-		output, err = stakePoolsInMinerSCInfo(t, configPath, "", true)
+		output, err = stakePoolsInMinerSCInfo(t, configPath, nil, true)
 		require.Nil(t, err, "error fetching Miner SC User pools")
 		require.Len(t, output, 1)
 
@@ -197,23 +199,18 @@ func TestSharderStake(testSetup *testing.T) {
 
 // waitForRoundsGT waits for at least r rounds passed
 func waitForRoundsGT(t *test.SystemTest, r int) error {
-	var (
-		lfb      = getLatestFinalizedBlock(t)
-		endRound = lfb.Round + int64(r)
-		checkTk  = time.NewTicker(5 * time.Second)
-	)
+	output, err := registerWallet(t, configPath)
+	require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-	for {
-		select {
-		case <-time.After(3 * time.Minute):
-			return fmt.Errorf("wait timeout")
-		case <-checkTk.C:
-			lfb = getLatestFinalizedBlock(t)
-			if lfb.Round > endRound {
-				return nil
-			}
-		}
+	var urls []string
+	for _, sharder := range getShardersList(t) {
+		urls = append(urls, getNodeBaseURL(sharder.Host, sharder.Port))
 	}
+
+	ctx, cancel := context.WithTimeout(t.Context(), 3*time.Minute)
+	defer cancel()
+	_, err = watcher.Watch(t, urls).WaitForNRounds(ctx, int64(r)+1)
+	return err
 }
 
 func waitForStakePoolActive(t *test.SystemTest) {
@@ -2,7 +2,6 @@ package cli_tests
 
 import (
 	"encoding/json"
-	"fmt"
 	"os"
 	"regexp"
 	"strings"
@@ -20,6 +19,7 @@ const sharderAccessDenied = "update_sharder_settings: access denied"
 
 func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic complexity 50 of func `
 	t := test.NewSystemTest(testSetup)
+	t.HoldLocks(test.Shared(test.MinerSCConfigLock))
 	mnConfig := getMinerSCConfiguration(t)
 
 	if _, err := os.Stat("./config/" + sharder01NodeDelegateWalletName + "_wallet.json"); err != nil {
@@ -421,7 +421,7 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 			}
 		}
 
-		output, err := minerSharderUpdateSettings(t, configPath, sharder01NodeDelegateWalletName, "--sharder", false)
+		output, err := minerSharderUpdateSettings(t, configPath, sharder01NodeDelegateWalletName, createParams(map[string]interface{}{"sharder": ""}), false)
 		require.NotNil(t, err, "expected error trying to update sharder node without id, but got output:", strings.Join(output, "\n"))
 		require.Len(t, output, 1)
 		require.Equal(t, "missing id flag", output[0])
@@ -496,15 +496,20 @@ func TestSharderUpdateSettings(testSetup *testing.T) { //nolint cyclomatic compl
 	})
 }
 
-func minerSharderUpdateSettings(t *test.SystemTest, cliConfigFilename, wallet, params string, retry bool) ([]string, error) {
+func minerSharderUpdateSettings(t *test.SystemTest, cliConfigFilename, wallet string, params cliutils.Flags, retry bool) ([]string, error) {
 	return minerSharderUpdateSettingsForWallet(t, cliConfigFilename, params, wallet, retry)
 }
 
-func minerSharderUpdateSettingsForWallet(t *test.SystemTest, cliConfigFilename, params, wallet string, retry bool) ([]string, error) {
+func minerSharderUpdateSettingsForWallet(t *test.SystemTest, cliConfigFilename string, params cliutils.Flags, wallet string, retry bool) ([]string, error) {
 	t.Logf("Updating Miner/Sharder node info...")
+	cmd := cliutils.Zwallet("mn-update-settings").
+		WithFlags(params).
+		WithSilent().
+		WithWallet(wallet + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, fmt.Sprintf("./zwallet mn-update-settings %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename), 3, time.Second)
+		return cmd.Run(t, 3, time.Second)
 	} else {
-		return cliutils.RunCommandWithoutRetry(fmt.Sprintf("./zwallet mn-update-settings %s --silent --wallet %s_wallet.json --configDir ./config --config %s", params, wallet, cliConfigFilename))
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
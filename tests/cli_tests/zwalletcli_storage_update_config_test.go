@@ -20,12 +20,13 @@ var settingUpdateSleepTime = time.Second * 60
 
 func TestStorageUpdateConfig(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagOwnerConfig)
 
 	if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 		t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 	}
 
-	t.RunSequentiallyWithTimeout("should allow update setting updates", 3*time.Minute, func(t *test.SystemTest) { // todo: too slow
+	t.RunWithLocksAndTimeout(test.Exclusive(test.StorageSCConfigLock), "should allow update setting updates", 3*time.Minute, func(t *test.SystemTest) { // todo: too slow
 		_ = initialiseTest(t, scOwnerWallet, false)
 
 		// ATM the owner is the only string setting and that is handled elsewhere
@@ -102,7 +103,7 @@ func TestStorageUpdateConfig(testSetup *testing.T) {
 		checkSettings(t, settingsAfter, *expectedChange)
 	})
 
-	t.RunSequentially("update by non-smartcontract owner should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.StorageSCConfigLock), "update by non-smartcontract owner should fail", func(t *test.SystemTest) {
 		// unused wallet, just added to avoid having the creating new wallet outputs
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
@@ -115,7 +116,7 @@ func TestStorageUpdateConfig(testSetup *testing.T) {
 		require.Equal(t, "update_settings: unauthorized access - only the owner can access", output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update with bad config key should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.StorageSCConfigLock), "update with bad config key should fail", func(t *test.SystemTest) {
 		// unused wallet, just added to avoid having the creating new wallet outputs
 		output, err := registerWallet(t, configPath)
 		require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
@@ -131,7 +132,7 @@ func TestStorageUpdateConfig(testSetup *testing.T) {
 			", can't set value "+value, output[0], strings.Join(output, "\n"))
 	})
 
-	t.RunSequentially("update max_read_price to invalid value should fail", func(t *test.SystemTest) {
+	t.RunWithLocks(test.Exclusive(test.StorageSCConfigLock), "update max_read_price to invalid value should fail", func(t *test.SystemTest) {
 		if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 			t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
 		}
@@ -232,17 +233,15 @@ func checkSettings(t *test.SystemTest, actual, expected settingMaps) {
 
 func updateStorageSCConfig(t *test.SystemTest, walletName string, param map[string]string, retry bool) ([]string, error) {
 	t.Logf("Updating storage config...")
-	p := createKeyValueParams(param)
-	cmd := fmt.Sprintf(
-		"./zwallet sc-update-config %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		walletName+"_wallet.json",
-		configPath,
-	)
+	cmd := cliutils.Zwallet("sc-update-config").
+		WithFlags(createKeyValueParams(param)).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*5)
+		return cmd.Run(t, 3, time.Second*5)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -258,11 +257,12 @@ func getStorageConfigMap(t *test.SystemTest) settingMaps {
 func getStorageSCConfig(t *test.SystemTest, cliConfigFilename string, retry bool) ([]string, error) {
 	cliutils.Wait(t, 5*time.Second)
 	t.Logf("Retrieving storage config...")
-	cmd := "./zwallet sc-config --wallet " + escapedTestName(t) + "_wallet.json --configDir ./config --config " + cliConfigFilename
-
+	cmd := cliutils.Zwallet("sc-config").
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
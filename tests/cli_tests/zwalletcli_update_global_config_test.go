@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -16,6 +15,7 @@ import (
 
 func TestUpdateGlobalConfig(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagOwnerConfig)
 
 	if _, err := os.Stat("./config/" + scOwnerWallet + "_wallet.json"); err != nil {
 		t.Skipf("SC owner wallet located at %s is missing", "./config/"+scOwnerWallet+"_wallet.json")
@@ -283,29 +283,27 @@ func getGlobalConfig(t *test.SystemTest, retry bool) ([]string, error) {
 
 func getGlobalConfigWithWallet(t *test.SystemTest, walletName string, retry bool) ([]string, error) {
 	t.Logf("Retrieving global config...")
-
-	cmd := "./zwallet global-config --silent --wallet " + walletName + "_wallet.json --configDir ./config --config " + configPath
-
+	cmd := cliutils.Zwallet("global-config").
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func updateGlobalConfigWithWallet(t *test.SystemTest, walletName string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Logf("Updating global config...")
-	p := createParams(param)
-	cmd := fmt.Sprintf(
-		"./zwallet global-update-config %s --silent --wallet %s --configDir ./config --config %s",
-		p,
-		walletName+"_wallet.json",
-		configPath,
-	)
-
+	cmd := cliutils.Zwallet("global-update-config").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*5)
+		return cmd.Run(t, 3, time.Second*5)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"regexp"
 	"strconv"
 	"strings"
@@ -19,6 +18,7 @@ import (
 
 func TestBridgeBurn(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagBridge)
 
 	t.Parallel()
 
@@ -119,72 +119,58 @@ func isEthereumAddress(src string) bool {
 
 func burnZcn(t *test.SystemTest, amount, bridgeClientConfigFile string, retry bool) ([]string, error) {
 	t.Logf("Burning ZCN tokens that will be minted for WZCN tokens...")
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-burn-zcn --token %s --path %s --bridge_config %s --wallet %s --configDir ./config --config %s",
-		amount,
-		configDir,
-		bridgeClientConfigFile,
-		escapedTestName(t)+"_wallet.json",
-		configPath,
-	)
+	cmd := cliutils.Zwallet("bridge-burn-zcn").
+		WithFlags(cliutils.Flags{"token": amount, "path": configDir, "bridge_config": bridgeClientConfigFile}).
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 6, time.Second*10)
+		return cmd.Run(t, 6, time.Second*10)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func burnEth(t *test.SystemTest, amount, bridgeClientConfigFile string, retry bool) ([]string, error) {
 	t.Logf("Burning WZCN tokens that will be minted for ZCN tokens...")
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-burn-eth --amount %s --path %s --bridge_config %s --retries 200",
-		amount,
-		configDir,
-		bridgeClientConfigFile,
-	)
-
-	cmd += fmt.Sprintf(" --wallet %s --configDir ./config --config %s ", escapedTestName(t)+"_wallet.json", configPath)
+	cmd := cliutils.Zwallet("bridge-burn-eth").
+		WithFlags(cliutils.Flags{"amount": amount, "path": configDir, "bridge_config": bridgeClientConfigFile, "retries": 200}).
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 6, time.Second*10)
+		return cmd.Run(t, 6, time.Second*10)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func getZcnBurnTicket(t *test.SystemTest, hash string, retry bool) ([]string, error) {
 	t.Logf("Get ZCN burn ticket...")
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-get-zcn-burn --hash %s --silent "+
-			"--configDir ./config --config %s --wallet %s --path %s",
-		hash,
-		configPath,
-		escapedTestName(t)+"_wallet.json",
-		configDir,
-	)
+	cmd := cliutils.Zwallet("bridge-get-zcn-burn").
+		WithFlags(cliutils.Flags{"hash": hash, "path": configDir}).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 6, time.Second*10)
+		return cmd.Run(t, 6, time.Second*10)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func getWrappedZcnBurnTicket(t *test.SystemTest, hash string, retry bool) ([]string, error) {
 	t.Logf("Get WZCN burn ticket...")
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-get-wzcn-burn --hash %s --silent "+
-			"--configDir ./config --config %s --wallet %s --path %s",
-		hash,
-		configPath,
-		escapedTestName(t)+"_wallet.json",
-		configDir,
-	)
+	cmd := cliutils.Zwallet("bridge-get-wzcn-burn").
+		WithFlags(cliutils.Flags{"hash": hash, "path": configDir}).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 
 	if retry {
-		return cliutils.RunCommand(t, cmd, 6, time.Second*10)
+		return cmd.Run(t, 6, time.Second*10)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"testing"
 
@@ -53,7 +52,7 @@ func prepareBridgeClientConfig(t *test.SystemTest) ([]string, error) {
 		"0xD8c9156e782C68EE671C09b6b92de76C97948432",
 		"0x0c2aa005C6FF9F4B46Ae566D9bc61E33B482D8E6",
 		"0xbD2048E2348b8Eb597D356AF23EAfAa246F88375",
-		"https://goerli.infura.io/v3/773bfe30452f40f998e5d0f2f8a29888 ",
+		"https://goerli.infura.io/v3/773bfe30452f40f998e5d0f2f8a29888",
 		75,
 		300000,
 		0,
@@ -64,21 +63,17 @@ func prepareBridgeClientConfig(t *test.SystemTest) ([]string, error) {
 
 // Use it to import account to the given home folder
 func prepareBridgeClientWallet(t *test.SystemTest) ([]string, error) {
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-import-account --%s %s --%s %q --%s %s",
-		OptionConfigFolder, configDir,
-		OptionMnemonic, mnemonic,
-		OptionKeyPassword, password,
-	)
-
-	cmd += fmt.Sprintf(" --wallet %s --configDir ./config --config %s ", escapedTestName(t)+"_wallet.json", configPath)
-
-	return cliutils.RunCommandWithoutRetry(cmd)
+	return cliutils.Zwallet("bridge-import-account").
+		WithFlags(cliutils.Flags{OptionConfigFolder: configDir, OptionMnemonic: mnemonic, OptionKeyPassword: password}).
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath).
+		RunWithoutRetry(t.Context())
 }
 
 // cmd: bridge-client-init
 func TestBridgeClientInit(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagBridge)
 
 	t.RunSequentially("Init bridge client config to default path and file", func(t *test.SystemTest) {
 		output, err := createDefaultClientBridgeConfig(t)
@@ -159,6 +154,7 @@ func TestBridgeClientInit(testSetup *testing.T) {
 // cmd: bridge-owner-init
 func TestBridgeOwnerInit(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagBridge)
 
 	t.Run("Init bridge owner config to default path and file", func(t *test.SystemTest) {
 		output, err := registerWallet(t, configPath)
@@ -197,25 +193,26 @@ func bridgeClientInit(
 	output, err := registerWallet(t, configPath)
 	require.Nil(t, err, "Failed to register wallet", strings.Join(output, "\n"))
 
-	cmd := "./zwallet bridge-client-init" +
-		" --password " + password +
-		" --ethereumaddress " + ethereumaddress +
-		" --bridgeaddress " + bridgeaddress +
-		" --wzcnaddress " + wzcnaddress +
-		" --ethereumnodeurl " + ethereumnodeurl +
-		" --consensusthreshold " + fmt.Sprintf("%.4f", consensusthreshold) +
-		" --gaslimit " + strconv.FormatInt(gaslimit, 10) +
-		" --value " + strconv.FormatInt(value, 10)
-
-	cmd += fmt.Sprintf(" --wallet %s --configDir ./config --config %s ", escapedTestName(t)+"_wallet.json", configPath)
-
+	cmd := cliutils.Zwallet("bridge-client-init").
+		WithFlags(cliutils.Flags{
+			"password":           password,
+			"ethereumaddress":    ethereumaddress,
+			"bridgeaddress":      bridgeaddress,
+			"wzcnaddress":        wzcnaddress,
+			"ethereumnodeurl":    ethereumnodeurl,
+			"consensusthreshold": fmt.Sprintf("%.4f", consensusthreshold),
+			"gaslimit":           gaslimit,
+			"value":              value,
+		}).
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 	for _, opt := range opts {
-		cmd = fmt.Sprintf(" %s --%s %s ", cmd, opt.name, opt.value)
+		cmd.WithFlag(opt.name, opt.value)
 	}
 
 	t.Log(cmd)
 
-	return cliutils.RunCommandWithoutRetry(cmd)
+	return cmd.RunWithoutRetry(t.Context())
 }
 
 // cmd: bridge-owner-init
@@ -227,25 +224,26 @@ func bridgeOwnerInit(
 ) ([]string, error) {
 	t.Logf("Init bridge owner config (owner.yaml) in HOME (~/.zcn) folder")
 
-	cmd := "./zwallet bridge-owner-init" +
-		" --password " + password +
-		" --ethereumaddress " + ethereumaddress +
-		" --bridgeaddress " + bridgeaddress +
-		" --wzcnaddress " + wzcnaddress +
-		" --authorizersaddress " + authorizersaddress +
-		" --ethereumnodeurl " + ethereumnodeurl +
-		" --gaslimit " + strconv.FormatInt(gaslimit, 10) +
-		" --value " + strconv.FormatInt(value, 10)
-
-	cmd += fmt.Sprintf(" --wallet %s --configDir ./config --config %s ", escapedTestName(t)+"_wallet.json", configPath)
-
+	cmd := cliutils.Zwallet("bridge-owner-init").
+		WithFlags(cliutils.Flags{
+			"password":           password,
+			"ethereumaddress":    ethereumaddress,
+			"bridgeaddress":      bridgeaddress,
+			"wzcnaddress":        wzcnaddress,
+			"authorizersaddress": authorizersaddress,
+			"ethereumnodeurl":    ethereumnodeurl,
+			"gaslimit":           gaslimit,
+			"value":              value,
+		}).
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 	for _, opt := range opts {
-		cmd = fmt.Sprintf(" %s --%s %s ", cmd, opt.name, opt.value)
+		cmd.WithFlag(opt.name, opt.value)
 	}
 
 	t.Log(cmd)
 
-	return cliutils.RunCommandWithoutRetry(cmd)
+	return cmd.RunWithoutRetry(t.Context())
 }
 
 func createDefaultClientBridgeConfig(t *test.SystemTest) ([]string, error) {
@@ -268,23 +266,24 @@ func runCreateBridgeClientTestConfig(
 	gaslimit, value int64,
 	opts ...*Option,
 ) ([]string, error) {
-	cmd := "./zwallet bridge-client-init" +
-		" --password " + password +
-		" --ethereumaddress " + ethereumaddress +
-		" --bridgeaddress " + bridgeaddress +
-		" --wzcnaddress " + wzcnaddress +
-		" --ethereumnodeurl " + ethereumnodeurl +
-		" --consensusthreshold " + fmt.Sprintf("%.4f", consensusthreshold) +
-		" --gaslimit " + strconv.FormatInt(gaslimit, 10) +
-		" --value " + strconv.FormatInt(value, 10)
-
-	cmd += fmt.Sprintf(" --wallet %s --configDir ./config --config %s ", escapedTestName(t)+"_wallet.json", configPath)
-
+	cmd := cliutils.Zwallet("bridge-client-init").
+		WithFlags(cliutils.Flags{
+			"password":           password,
+			"ethereumaddress":    ethereumaddress,
+			"bridgeaddress":      bridgeaddress,
+			"wzcnaddress":        wzcnaddress,
+			"ethereumnodeurl":    ethereumnodeurl,
+			"consensusthreshold": fmt.Sprintf("%.4f", consensusthreshold),
+			"gaslimit":           gaslimit,
+			"value":              value,
+		}).
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 	for _, opt := range opts {
-		cmd = fmt.Sprintf(" %s --%s %s ", cmd, opt.name, opt.value)
+		cmd.WithFlag(opt.name, opt.value)
 	}
 
-	return cliutils.RunCommandWithoutRetry(cmd)
+	return cmd.RunWithoutRetry(t.Context())
 }
 
 func WithOption(name, value string) *Option {
@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"io/fs"
 	"log"
 	"os"
@@ -29,6 +28,7 @@ const (
 
 func TestEthRegisterAccount(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagBridge)
 
 	t.RunSequentially("Register ethereum account in local key storage", func(t *test.SystemTest) {
 		deleteDefaultAccountInStorage(t, address)
@@ -58,32 +58,28 @@ func importAccount(t *test.SystemTest, password, mnemonic string, retry bool) ([
 	t.Logf("Register ethereum account using mnemonic and protected with password...")
 	output, err := registerWallet(t, configPath)
 	require.Nil(t, err, "Unexpected register wallet failure", strings.Join(output, "\n"))
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-import-account --password %s --mnemonic \"%s\" --silent "+
-			"--configDir ./config --config %s --wallet %s --path %s",
-		password,
-		mnemonic,
-		configPath,
-		escapedTestName(t)+"_wallet.json",
-		configDir,
-	)
-
+	cmd := cliutils.Zwallet("bridge-import-account").
+		WithFlags(cliutils.Flags{"password": password, "mnemonic": mnemonic, "path": configDir}).
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func listAccounts(t *test.SystemTest, retry bool) ([]string, error) {
 	t.Logf("List ethereum accounts...")
-	cmd := fmt.Sprintf("./zwallet bridge-list-accounts --path %s", configDir)
-	cmd += fmt.Sprintf(" --wallet %s --configDir ./config --config %s ", escapedTestName(t)+"_wallet.json", configPath)
-
+	cmd := cliutils.Zwallet("bridge-list-accounts").
+		WithFlag("path", configDir).
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -14,6 +13,7 @@ import (
 
 func TestListAuthorizers(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagBridge)
 
 	t.Parallel()
 
@@ -27,14 +27,12 @@ func TestListAuthorizers(testSetup *testing.T) {
 // nolint
 func getAuthorizersList(t *test.SystemTest, retry bool) ([]string, error) {
 	t.Logf("Getting  list of authorizers...")
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-list-auth --silent "+
-			"--configDir ./config --config %s",
-		configPath,
-	)
+	cmd := cliutils.Zwallet("bridge-list-auth").
+		WithSilent().
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
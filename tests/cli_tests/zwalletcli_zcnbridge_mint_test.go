@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -19,6 +18,7 @@ const (
 // todo: enable tests
 func TestBridgeMint(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagBridge)
 
 	t.Parallel()
 
@@ -44,31 +44,27 @@ func TestBridgeMint(testSetup *testing.T) {
 // nolint
 func mintZcnTokens(t *test.SystemTest, retry bool) ([]string, error) {
 	t.Logf("Mint ZCN tokens using WZCN burn ticket...")
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-mint-zcn --silent "+
-			"--configDir ./config --config %s --path %s",
-		configPath,
-		configDir,
-	)
+	cmd := cliutils.Zwallet("bridge-mint-zcn").
+		WithFlag("path", configDir).
+		WithSilent().
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 // nolint
 func mintWrappedZcnTokens(t *test.SystemTest, retry bool) ([]string, error) {
 	t.Logf("Mint WZCN tokens using ZCN burn ticket...")
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-mint-wzcn --silent "+
-			"--configDir ./config --config %s --path %s",
-		configPath,
-		configDir,
-	)
+	cmd := cliutils.Zwallet("bridge-mint-wzcn").
+		WithFlag("path", configDir).
+		WithSilent().
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"os"
 	"strings"
 	"testing"
@@ -35,6 +34,7 @@ var (
 
 func TestZCNBridgeGlobalSettings(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagBridge)
 
 	if _, err := os.Stat("./config/" + zcnscOwner + "_wallet.json"); err != nil {
 		t.Skipf("SC owner wallet located at %s is missing", "./config/"+zcnscOwner+"_wallet.json")
@@ -130,32 +130,28 @@ func getZCNBridgeGlobalSCConfig(t *test.SystemTest, cliConfigFilename string, re
 	cliutils.Wait(t, 5*time.Second)
 	t.Log("Retrieving zcnc bridge global config...")
 
-	cmd :=
-		"./zwallet bridge-config --silent --wallet " +
-			escapedTestName(t) +
-			"_wallet.json --configDir ./config --config " +
-			cliConfigFilename
-
+	cmd := cliutils.Zwallet("bridge-config").
+		WithSilent().
+		WithWallet(escapedTestName(t) + "_wallet.json").
+		WithConfig(cliConfigFilename)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
 
 func updateZCNBridgeSCConfig(t *test.SystemTest, walletName string, param map[string]interface{}, retry bool) ([]string, error) {
 	t.Log("Updating zcnsc bridge global config...")
 
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-config-update %s --silent --wallet %s --configDir ./config --config %s",
-		createParams(param),
-		walletName+"_wallet.json",
-		configPath,
-	)
-
+	cmd := cliutils.Zwallet("bridge-config-update").
+		WithFlags(createParams(param)).
+		WithSilent().
+		WithWallet(walletName + "_wallet.json").
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
@@ -1,7 +1,6 @@
 package cli_tests
 
 import (
-	"fmt"
 	"strings"
 	"testing"
 	"time"
@@ -18,6 +17,7 @@ const (
 
 func TestBridgeVerify(testSetup *testing.T) {
 	t := test.NewSystemTest(testSetup)
+	t.Tag(test.TagBridge)
 
 	t.Parallel()
 
@@ -33,15 +33,13 @@ func TestBridgeVerify(testSetup *testing.T) {
 
 func verifyBridgeTransaction(t *test.SystemTest, address string, retry bool) ([]string, error) { // nolint
 	t.Logf("verifying ethereum transaction...")
-	cmd := fmt.Sprintf(
-		"./zwallet bridge-verify --hash %s --silent --configDir ./config --config %s --path %s",
-		address,
-		configPath,
-		configDir,
-	)
+	cmd := cliutils.Zwallet("bridge-verify").
+		WithFlags(cliutils.Flags{"hash": address, "path": configDir}).
+		WithSilent().
+		WithConfig(configPath)
 	if retry {
-		return cliutils.RunCommand(t, cmd, 3, time.Second*2)
+		return cmd.Run(t, 3, time.Second*2)
 	} else {
-		return cliutils.RunCommandWithoutRetry(cmd)
+		return cmd.RunWithoutRetry(t.Context())
 	}
 }
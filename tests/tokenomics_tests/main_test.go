@@ -31,5 +31,5 @@ func TestMain(m *testing.M) {
 		log.Printf("Default test case timeout is [%v]", test.DefaultTestTimeout)
 	}
 
-	os.Exit(m.Run())
+	os.Exit(test.Finish(m.Run()))
 }